@@ -58,3 +58,12 @@ func J2000Century(jde float64) float64 {
 	// (25.1) p. 163.
 	return (jde - J2000) / JulianCentury
 }
+
+// J2000Century2 returns the number of Julian centuries since J2000 of a
+// julian day given in two parts, day + frac, as by julian.JD2.
+//
+// Differencing day from J2000 before adding frac keeps the precision of
+// frac that would be lost in forming a single julian day.
+func J2000Century2(day, frac float64) float64 {
+	return ((day - J2000) + frac) / JulianCentury
+}
@@ -0,0 +1,426 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Body: A common interface to positions of the Sun, Moon, planets, and
+// other bodies.
+//
+// Chapter packages compute positions with functions of differing
+// signatures.  Type Body gives those positions a single form, geocentric
+// equatorial coordinates and distance, so that generic tools can work with
+// any body.  Adapters are provided for each of the position sources of the
+// library:
+//
+//	Sun            solar, solarxyz, chapters 25 and 26
+//	Moon           moonposition, chapter 47
+//	Planet         planetposition and elliptic, chapters 32 and 33
+//	Pluto          pluto, chapter 37
+//	Elliptic       elliptic, chapter 33
+//	Parabolic      parabolic, chapter 34
+//	NearParabolic  nearparabolic, chapter 35
+//	Star           apparent, chapter 23
+//
+// Separation and Conjunction are generic tools that accept any Body.
+package body
+
+import (
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/angle"
+	"github.com/mooncaker816/learnmeeus/v3/apparent"
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/conjunction"
+	"github.com/mooncaker816/learnmeeus/v3/coord"
+	"github.com/mooncaker816/learnmeeus/v3/elliptic"
+	"github.com/mooncaker816/learnmeeus/v3/kepler"
+	"github.com/mooncaker816/learnmeeus/v3/moonposition"
+	"github.com/mooncaker816/learnmeeus/v3/nearparabolic"
+	"github.com/mooncaker816/learnmeeus/v3/nutation"
+	"github.com/mooncaker816/learnmeeus/v3/parabolic"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/mooncaker816/learnmeeus/v3/pluto"
+	"github.com/mooncaker816/learnmeeus/v3/precess"
+	"github.com/mooncaker816/learnmeeus/v3/solar"
+	"github.com/mooncaker816/learnmeeus/v3/solarxyz"
+	"github.com/soniakeys/unit"
+)
+
+// Body is the position contract satisfied by all adapters of this package.
+// 天体位置的统一接口
+type Body interface {
+	// Apparent returns geocentric apparent right ascension and
+	// declination, referenced to the true equator and equinox of date,
+	// and distance Δ in AU.
+	Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64)
+	// Astrometric returns geocentric astrometric right ascension and
+	// declination, referenced to the mean equator and equinox of J2000,
+	// and distance Δ in AU.
+	Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64)
+}
+
+// Sun is a Body for the Sun.
+// 太阳
+//
+// Earth must be a valid Planet object for Earth.
+type Sun struct {
+	Earth pp.Planet
+}
+
+// Apparent returns apparent equatorial coordinates of the Sun, as
+// computed by solar.ApparentEquatorialVSOP87.
+func (s Sun) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return solar.ApparentEquatorialVSOP87(s.Earth, jde)
+}
+
+// Astrometric returns J2000 equatorial coordinates of the Sun, as computed
+// by solarxyz.PositionJ2000.
+func (s Sun) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	x, y, z := solarxyz.PositionJ2000(s.Earth, jde)
+	return xyzToEq(x, y, z)
+}
+
+// Moon is a Body for the Moon.
+// 月亮
+//
+// Positions are computed with moonposition.Position.  Distance Δ is between
+// the centers of the Earth and Moon, converted to AU.
+type Moon struct{}
+
+// Apparent returns apparent equatorial coordinates of the Moon.
+//
+// Nutation in longitude and the true obliquity of date are applied as in
+// Example 47.a.
+func (Moon) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	λ, β, Δkm := moonposition.Position(jde)
+	Δψ, Δε := nutation.Nutation(jde)
+	sε, cε := (nutation.MeanObliquity(jde) + Δε).Sincos()
+	α, δ = coord.EclToEq(λ+Δψ, β, sε, cε)
+	return α, δ, Δkm / base.AU
+}
+
+// Astrometric returns J2000 equatorial coordinates of the Moon.
+//
+// The mean position of date is precessed to J2000 with
+// precess.EclipticPosition.
+func (Moon) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	λ, β, Δkm := moonposition.Position(jde)
+	eclFrom := &coord.Ecliptic{Lat: β, Lon: λ}
+	eclTo := &coord.Ecliptic{}
+	precess.EclipticPosition(eclFrom, eclTo,
+		base.JDEToJulianYear(jde), 2000, 0, 0)
+	α, δ = coord.EclToEq(eclTo.Lon, eclTo.Lat, base.SOblJ2000, base.COblJ2000)
+	return α, δ, Δkm / base.AU
+}
+
+// Planet is a Body for a major planet.
+// 行星
+//
+// Planet and Earth must be valid Planet objects for the observed planet
+// and for Earth.
+type Planet struct {
+	Planet pp.Planet
+	Earth  pp.Planet
+}
+
+// Apparent returns apparent equatorial coordinates of the planet, as
+// computed by elliptic.Position.
+func (p Planet) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	α, δ = elliptic.Position(p.Planet, p.Earth, jde)
+	_, _, Δ = p.Astrometric(jde)
+	return
+}
+
+// Astrometric returns J2000 astrometric coordinates of the planet.
+func (p Planet) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return astrometricJ2000(func(jde float64) (x, y, z float64) {
+		l, b, r := p.Planet.Position2000(jde)
+		l, b = pp.ToFK5(l, b, jde)
+		return eclToXYZ(l, b, r)
+	}, jde, p.Earth)
+}
+
+// Pluto is a Body for Pluto.
+// 冥王星
+//
+// Positions are computed with pluto.Heliocentric and are valid only for
+// the years 1885 to 2099.  Earth must be a valid Planet object for Earth.
+type Pluto struct {
+	Earth pp.Planet
+}
+
+// Apparent returns apparent equatorial coordinates of Pluto.
+func (p Pluto) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return apparentOf(p, jde)
+}
+
+// Astrometric returns J2000 astrometric coordinates of Pluto, as computed
+// by pluto.Astrometric.
+func (p Pluto) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return astrometricJ2000(func(jde float64) (x, y, z float64) {
+		return eclToXYZ(pluto.Heliocentric(jde))
+	}, jde, p.Earth)
+}
+
+// orbit holds the orientation of an orbit, as constants of (33.8) p. 229.
+type orbit struct {
+	a, b, c float64
+	A, B, C unit.Angle
+	ω       unit.Angle
+}
+
+// newOrbit computes orientation constants for an orbit with inclination
+// i, argument of perihelion ω, and longitude of ascending node Ω, all
+// referenced to the ecliptic and equinox of J2000.
+func newOrbit(i, ω, Ω unit.Angle) orbit {
+	const sε = base.SOblJ2000
+	const cε = base.COblJ2000
+	sΩ, cΩ := Ω.Sincos()
+	si, ci := i.Sincos()
+	// (33.7) p. 228
+	F := cΩ
+	G := sΩ * cε
+	H := sΩ * sε
+	P := -sΩ * ci
+	Q := cΩ*ci*cε - si*sε
+	R := cΩ*ci*sε + si*cε
+	// (33.8) p. 229
+	return orbit{
+		a: math.Hypot(F, P),
+		b: math.Hypot(G, Q),
+		c: math.Hypot(H, R),
+		A: unit.Angle(math.Atan2(F, P)),
+		B: unit.Angle(math.Atan2(G, Q)),
+		C: unit.Angle(math.Atan2(H, R)),
+		ω: ω,
+	}
+}
+
+// xyz returns heliocentric J2000 equatorial coordinates for true anomaly
+// ν and radius vector r.
+func (o *orbit) xyz(ν unit.Angle, r float64) (x, y, z float64) {
+	// (33.9) p. 229
+	x = r * o.a * (o.A + o.ω + ν).Sin()
+	y = r * o.b * (o.B + o.ω + ν).Sin()
+	z = r * o.c * (o.C + o.ω + ν).Sin()
+	return
+}
+
+// Elliptic is a Body for an object in elliptic orbit around the Sun.
+// 椭圆轨道天体
+type Elliptic struct {
+	k     elliptic.Elements
+	earth pp.Planet
+	o     orbit
+}
+
+// NewElliptic constructs an Elliptic object.
+//
+// Elements k must be referenced to the ecliptic and equinox of J2000.
+// Argument earth must be a valid Planet object for Earth.
+func NewElliptic(k *elliptic.Elements, earth pp.Planet) *Elliptic {
+	return &Elliptic{*k, earth, newOrbit(k.Inc, k.ArgP, k.Node)}
+}
+
+// Apparent returns apparent equatorial coordinates of the object.
+func (e *Elliptic) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return apparentOf(e, jde)
+}
+
+// Astrometric returns J2000 astrometric coordinates of the object, as
+// computed by elliptic.Elements.Position.
+func (e *Elliptic) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	k := &e.k
+	// (33.6) p. 227
+	n := base.K / k.Axis / math.Sqrt(k.Axis)
+	return astrometricJ2000(func(jde float64) (x, y, z float64) {
+		M := unit.Angle(n * (jde - k.TimeP))
+		E, err := kepler.Kepler2b(k.Ecc, M, 15)
+		if err != nil {
+			E = kepler.Kepler3(k.Ecc, M)
+		}
+		return e.o.xyz(kepler.True(E, k.Ecc), kepler.Radius(E, k.Ecc, k.Axis))
+	}, jde, e.earth)
+}
+
+// Parabolic is a Body for an object in parabolic orbit around the Sun.
+// 抛物线轨道天体
+type Parabolic struct {
+	k     parabolic.Elements
+	earth pp.Planet
+	o     orbit
+}
+
+// NewParabolic constructs a Parabolic object.
+//
+// Elements k give time of perihelion and perihelion distance.  Arguments
+// inc, argP, and node are inclination, argument of perihelion, and
+// longitude of ascending node, referenced to the ecliptic and equinox of
+// J2000.  Argument earth must be a valid Planet object for Earth.
+func NewParabolic(k *parabolic.Elements, inc, argP, node unit.Angle, earth pp.Planet) *Parabolic {
+	return &Parabolic{*k, earth, newOrbit(inc, argP, node)}
+}
+
+// Apparent returns apparent equatorial coordinates of the object.
+func (p *Parabolic) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return apparentOf(p, jde)
+}
+
+// Astrometric returns J2000 astrometric coordinates of the object.
+func (p *Parabolic) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return astrometricJ2000(func(jde float64) (x, y, z float64) {
+		return p.o.xyz(p.k.AnomalyDistance(jde))
+	}, jde, p.earth)
+}
+
+// NearParabolic is a Body for an object in near-parabolic orbit around
+// the Sun.
+// 近抛物线轨道天体
+type NearParabolic struct {
+	k     nearparabolic.Elements
+	earth pp.Planet
+	o     orbit
+}
+
+// NewNearParabolic constructs a NearParabolic object.
+//
+// Arguments are as for NewParabolic.
+func NewNearParabolic(k *nearparabolic.Elements, inc, argP, node unit.Angle, earth pp.Planet) *NearParabolic {
+	return &NearParabolic{*k, earth, newOrbit(inc, argP, node)}
+}
+
+// Apparent returns apparent equatorial coordinates of the object.
+//
+// Results are NaN if the near-parabolic algorithm fails to converge.
+func (p *NearParabolic) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return apparentOf(p, jde)
+}
+
+// Astrometric returns J2000 astrometric coordinates of the object.
+//
+// Results are NaN if the near-parabolic algorithm fails to converge.
+func (p *NearParabolic) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	return astrometricJ2000(func(jde float64) (x, y, z float64) {
+		ν, r, err := p.k.AnomalyDistance(jde)
+		if err != nil {
+			return math.NaN(), math.NaN(), math.NaN()
+		}
+		return p.o.xyz(ν, r)
+	}, jde, p.earth)
+}
+
+// Star is a Body for a star.
+// 恒星
+//
+// RA and Dec are the catalog position for the mean equator and equinox of
+// Epoch, given as a Julian year.  PMRA and PMDec are annual proper motions.
+//
+// Distance Δ is returned as +Inf.
+type Star struct {
+	RA    unit.RA
+	Dec   unit.Angle
+	Epoch float64
+	PMRA  unit.HourAngle
+	PMDec unit.Angle
+}
+
+// Apparent returns apparent equatorial coordinates of the star, as
+// computed by apparent.Position.
+func (s Star) Apparent(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	eq := &coord.Equatorial{RA: s.RA, Dec: s.Dec}
+	apparent.Position(eq, eq, s.Epoch, base.JDEToJulianYear(jde),
+		s.PMRA, s.PMDec)
+	return eq.RA, eq.Dec, math.Inf(1)
+}
+
+// Astrometric returns the position of the star at jde, with proper motion
+// applied, referenced to the mean equator and equinox of J2000.
+func (s Star) Astrometric(jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	eq := &coord.Equatorial{RA: s.RA, Dec: s.Dec}
+	epoch := base.JDEToJulianYear(jde)
+	precess.Position(eq, eq, s.Epoch, epoch, s.PMRA, s.PMDec)
+	precess.Position(eq, eq, epoch, 2000, 0, 0)
+	return eq.RA, eq.Dec, math.Inf(1)
+}
+
+// Separation returns the angular separation of the apparent positions of
+// two bodies.
+// 两天体视位置之间的角距
+func Separation(b1, b2 Body, jde float64) unit.Angle {
+	α1, δ1, _ := b1.Apparent(jde)
+	α2, δ2, _ := b2.Apparent(jde)
+	return angle.Sep(α1.Angle(), δ1, α2.Angle(), δ2)
+}
+
+// Conjunction returns the time of conjunction in right ascension of two
+// bodies.
+// 计算两天体赤经相合的时刻
+//
+// Apparent positions are computed at five times equally spaced from jde1
+// to jde5 and the conjunction is found with conjunction.Planetary.
+//
+// Result jde is the time of conjunction.  Δδ is the amount that b2 is north
+// of b1 at that time.  An error is returned if the conjunction does not
+// fall within the interval.
+func Conjunction(b1, b2 Body, jde1, jde5 float64) (jde float64, Δδ unit.Angle, err error) {
+	r1 := make([]unit.Angle, 5)
+	d1 := make([]unit.Angle, 5)
+	r2 := make([]unit.Angle, 5)
+	d2 := make([]unit.Angle, 5)
+	for i := range r1 {
+		j := jde1 + float64(i)*(jde5-jde1)/4
+		α1, δ1, _ := b1.Apparent(j)
+		α2, δ2, _ := b2.Apparent(j)
+		r1[i], d1[i] = α1.Angle(), δ1
+		// keep the difference continuous where right ascension wraps
+		// through 0ʰ.
+		dr := math.Remainder((α2 - α1).Rad(), 2*math.Pi)
+		r2[i], d2[i] = r1[i]+unit.Angle(dr), δ2
+	}
+	return conjunction.Planetary(jde1, jde5, r1, d1, r2, d2)
+}
+
+// apparentOf returns apparent coordinates of b from its astrometric
+// coordinates, applying precession, nutation, and aberration with
+// apparent.Position.  Light time is accounted for in the astrometric
+// coordinates.
+func apparentOf(b Body, jde float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	α, δ, Δ = b.Astrometric(jde)
+	eq := &coord.Equatorial{RA: α, Dec: δ}
+	apparent.Position(eq, eq, 2000, base.JDEToJulianYear(jde), 0, 0)
+	return eq.RA, eq.Dec, Δ
+}
+
+// astrometricJ2000 returns astrometric J2000 coordinates and distance of a
+// body.
+//
+// Argument f is a function that returns heliocentric J2000 equatorial
+// rectangular coordinates of the body.  The computation is that of
+// elliptic.AstrometricJ2000, (33.10) p. 229, but returns distance rather
+// than elongation.
+func astrometricJ2000(f func(float64) (x, y, z float64), jde float64, e pp.Planet) (α unit.RA, δ unit.Angle, Δ float64) {
+	X, Y, Z := solarxyz.PositionJ2000(e, jde)
+	x, y, z := f(jde)
+	Δ = math.Sqrt((X+x)*(X+x) + (Y+y)*(Y+y) + (Z+z)*(Z+z))
+	x, y, z = f(jde - base.LightTime(Δ))
+	return xyzToEq(X+x, Y+y, Z+z)
+}
+
+// eclToXYZ converts heliocentric J2000 ecliptic coordinates to J2000
+// equatorial rectangular coordinates, as (37.1) p. 264.
+func eclToXYZ(l, b unit.Angle, r float64) (x, y, z float64) {
+	const sε, cε = base.SOblJ2000, base.COblJ2000
+	sl, cl := l.Sincos()
+	sb, cb := b.Sincos()
+	x = r * cl * cb
+	y = r * (sl*cb*cε - sb*sε)
+	z = r * (sl*cb*sε + sb*cε)
+	return
+}
+
+// xyzToEq converts rectangular equatorial coordinates to right ascension,
+// declination, and distance.
+func xyzToEq(x, y, z float64) (α unit.RA, δ unit.Angle, Δ float64) {
+	Δ = math.Sqrt(x*x + y*y + z*z)
+	α = unit.RAFromRad(math.Atan2(y, x))
+	δ = unit.Angle(math.Asin(z / Δ))
+	return
+}
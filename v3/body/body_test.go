@@ -0,0 +1,172 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package body_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/body"
+	"github.com/mooncaker816/learnmeeus/v3/elliptic"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/mooncaker816/learnmeeus/v3/parabolic"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/mooncaker816/learnmeeus/v3/solar"
+	"github.com/soniakeys/unit"
+)
+
+func keplerian(t *testing.T, ibody int) pp.Planet {
+	p, err := pp.NewKeplerian(ibody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// near returns true if two positions agree within tol.
+func near(α1 unit.RA, δ1 unit.Angle, α2 unit.RA, δ2 unit.Angle, tol unit.Angle) bool {
+	dα := math.Remainder((α1-α2).Rad(), 2*math.Pi) * δ1.Cos()
+	return math.Abs(dα) < tol.Rad() && math.Abs((δ1-δ2).Rad()) < tol.Rad()
+}
+
+func TestSun(t *testing.T) {
+	// Example 25.a, p. 165.
+	jde := julian.CalendarGregorianToJD(1992, 10, 13)
+	s := body.Sun{Earth: keplerian(t, pp.Earth)}
+	α, δ, Δ := s.Apparent(jde)
+	αw, δw := solar.ApparentEquatorial(jde)
+	if !near(α, δ, αw, δw, unit.AngleFromMin(1)) || math.Abs(Δ-.99766) > 1e-4 {
+		t.Error("apparent:", α.Hour(), δ.Deg(), Δ)
+	}
+	// Example 26.a, p. 175, gives J2000 rectangular coordinates.
+	α, δ, Δ = s.Astrometric(jde)
+	αw = unit.RAFromRad(math.Atan2(-.31316793, -.93739590))
+	δw = unit.Angle(math.Asin(-.13577924 / Δ))
+	if !near(α, δ, αw, δw, unit.AngleFromMin(1)) {
+		t.Error("astrometric:", α.Hour(), δ.Deg(), Δ)
+	}
+}
+
+func TestMoon(t *testing.T) {
+	// Example 47.a, p. 342.
+	jde := julian.CalendarGregorianToJD(1992, 4, 12)
+	α, δ, Δ := body.Moon{}.Apparent(jde)
+	if !near(α, δ, unit.RAFromDeg(134.688470), unit.AngleFromDeg(13.768368),
+		unit.AngleFromSec(.1)) || math.Abs(Δ*base.AU-368409.7) > .1 {
+		t.Error(α.Deg(), δ.Deg(), Δ*base.AU)
+	}
+	// Astrometric differs from apparent by precession since J2000 and
+	// nutation, several arc minutes.
+	αa, δa, _ := body.Moon{}.Astrometric(jde)
+	if near(α, δ, αa, δa, unit.AngleFromMin(1)) ||
+		!near(α, δ, αa, δa, unit.AngleFromMin(10)) {
+		t.Error("astrometric:", αa.Deg(), δa.Deg())
+	}
+}
+
+func TestPlanet(t *testing.T) {
+	// Example 33.a, p. 225.
+	p := body.Planet{Planet: keplerian(t, pp.Venus), Earth: keplerian(t, pp.Earth)}
+	α, δ, Δ := p.Apparent(2448976.5)
+	if !near(α, δ, unit.NewRA(21, 4, 41.454),
+		unit.NewAngle('-', 18, 53, 16.84), unit.AngleFromMin(1)) ||
+		math.Abs(Δ-.910947) > 1e-3 {
+		t.Error(α.Hour(), δ.Deg(), Δ)
+	}
+}
+
+func TestElliptic(t *testing.T) {
+	// Example 33.b, p. 232.
+	k := &elliptic.Elements{
+		TimeP: julian.CalendarGregorianToJD(1990, 10, 28.54502),
+		Axis:  2.2091404,
+		Ecc:   .8502196,
+		Inc:   unit.AngleFromDeg(11.94524),
+		Node:  unit.AngleFromDeg(334.75006),
+		ArgP:  unit.AngleFromDeg(186.23352),
+	}
+	e := keplerian(t, pp.Earth)
+	jde := julian.CalendarGregorianToJD(1990, 10, 6)
+	α, δ, _ := body.NewElliptic(k, e).Astrometric(jde)
+	αw, δw, _ := k.Position(jde, e)
+	if !near(α, δ, αw, δw, unit.AngleFromSec(1e-3)) {
+		t.Error(α.Hour(), δ.Deg())
+	}
+	if !near(α, δ, unit.NewRA(10, 34, 14.2), unit.NewAngle(' ', 19, 9, 31),
+		unit.AngleFromMin(1)) {
+		t.Error(α.Hour(), δ.Deg())
+	}
+}
+
+func TestParabolic(t *testing.T) {
+	// An elliptic orbit of eccentricity near 1 approximates a parabolic
+	// orbit of the same perihelion distance near perihelion.
+	T := julian.CalendarGregorianToJD(1998, 4, 14.4358)
+	q := 1.487469
+	inc := unit.AngleFromDeg(30)
+	argP := unit.AngleFromDeg(100)
+	node := unit.AngleFromDeg(60)
+	e := keplerian(t, pp.Earth)
+	p := body.NewParabolic(&parabolic.Elements{TimeP: T, PDis: q},
+		inc, argP, node, e)
+	const ecc = .99999
+	k := &elliptic.Elements{TimeP: T, Axis: q / (1 - ecc), Ecc: ecc,
+		Inc: inc, ArgP: argP, Node: node}
+	jde := T + 5
+	α, δ, Δ := p.Apparent(jde)
+	αe, δe, Δe := body.NewElliptic(k, e).Apparent(jde)
+	if !near(α, δ, αe, δe, unit.AngleFromSec(5)) || math.Abs(Δ-Δe) > 1e-5 {
+		t.Error(α.Hour(), δ.Deg(), Δ, αe.Hour(), δe.Deg(), Δe)
+	}
+}
+
+func TestStar(t *testing.T) {
+	// Example 23.a, p. 152.
+	s := body.Star{
+		RA:    unit.NewRA(2, 44, 11.986),
+		Dec:   unit.NewAngle(' ', 49, 13, 42.48),
+		Epoch: 2000,
+		PMRA:  unit.HourAngleFromSec(.03425),
+		PMDec: unit.AngleFromSec(-.0895),
+	}
+	jde := julian.CalendarGregorianToJD(2028, 11, 13.19)
+	α, δ, Δ := s.Apparent(jde)
+	if !near(α, δ, unit.NewRA(2, 46, 14.390), unit.NewAngle(' ', 49, 21, 7.45),
+		unit.AngleFromSec(.01)) || !math.IsInf(Δ, 1) {
+		t.Error(α.Hour(), δ.Deg(), Δ)
+	}
+	// 28.87 years of proper motion.
+	α, δ, _ = s.Astrometric(jde)
+	αw := s.RA.Add(s.PMRA.Mul(28.87))
+	δw := s.Dec + s.PMDec.Mul(28.87)
+	if !near(α, δ, αw, δw, unit.AngleFromSec(.01)) {
+		t.Error(α.Hour(), δ.Deg())
+	}
+}
+
+func TestConjunction(t *testing.T) {
+	// Example 18.a, p. 117.  Mercury passes 2°08′ north of Venus, 1991
+	// August 7 at 5ʰ.
+	e := keplerian(t, pp.Earth)
+	mercury := body.Planet{Planet: keplerian(t, pp.Mercury), Earth: e}
+	venus := body.Planet{Planet: keplerian(t, pp.Venus), Earth: e}
+	jde1 := julian.CalendarGregorianToJD(1991, 8, 5)
+	jde, Δδ, err := body.Conjunction(venus, mercury, jde1, jde1+4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := julian.CalendarGregorianToJD(1991, 8, 7.2375)
+	if math.Abs(jde-want) > .1 || math.Abs(Δδ.Deg()-2.1333) > .05 {
+		t.Error(jde-want, Δδ.Deg())
+	}
+	α1, _, _ := mercury.Apparent(jde)
+	α2, _, _ := venus.Apparent(jde)
+	if math.Abs((α1 - α2).Rad()) > 1e-7 {
+		t.Error("right ascensions differ:", α1, α2)
+	}
+	if sep := body.Separation(mercury, venus, jde); math.Abs(sep.Rad()-Δδ.Rad()) > 1e-5 {
+		t.Error("separation:", sep.Deg(), Δδ.Deg())
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package chinese
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/mooncaker816/learnmeeus/v3/moonphase"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+)
+
+// ErrorInvalidDate is returned for a Chinese date that does not exist, such
+// as a leap month in a year without one.
+var ErrorInvalidDate = errors.New("Invalid date")
+
+// Month is a month of the Chinese calendar.
+type Month struct {
+	Year   int     // Gregorian year in which the Chinese year begins
+	Number int     // 1 to 12
+	Leap   bool    // true for a leap month, 闰月
+	Start  float64 // julian day of the first day, see below
+	Days   int     // 29 or 30
+}
+
+// Julian days of Chinese calendar dates, such as Month.Start, are given for
+// 0h UT of the same calendar date as in China, as with
+// julian.CalendarGregorianToJD.
+
+var monthNames = [12]string{"正月", "二月", "三月", "四月", "五月", "六月",
+	"七月", "八月", "九月", "十月", "冬月", "腊月"}
+
+// String returns the Chinese name of the month, "闰二月" for example.
+func (m Month) String() string {
+	if m.Number < 1 || m.Number > 12 {
+		return fmt.Sprintf("Month(%d)", m.Number)
+	}
+	if m.Leap {
+		return "闰" + monthNames[m.Number-1]
+	}
+	return monthNames[m.Number-1]
+}
+
+// gregorian returns the Gregorian calendar date of jd.
+func gregorian(jd float64) (y, m, d int) {
+	y, mon, d := julian.JDToTime(jd).Date()
+	return y, int(mon), d
+}
+
+// newMoonDay returns the date in China of New Moon of lunation k of (49.2).
+func newMoonDay(k int) float64 {
+	return civilDay(moonphase.New(2000 + float64(k)/12.3685))
+}
+
+// sui returns the months from the eleventh month of Chinese year y-1, the
+// month containing the December solstice of Gregorian year y-1, through the
+// month before the eleventh month of year y.
+func sui(y int, e pp.Planet) []Month {
+	d1 := civilDay(TermJDE(y-1, Dongzhi, e))
+	d2 := civilDay(TermJDE(y, Dongzhi, e))
+	k := int(math.Floor((d1 - 2451550.09766) / 29.530588861))
+	for newMoonDay(k) > d1 {
+		k--
+	}
+	for newMoonDay(k+1) <= d1 {
+		k++
+	}
+	// month starts, through the start of the eleventh month of year y
+	var s []float64
+	for ; ; k++ {
+		n := newMoonDay(k)
+		if n > d2 {
+			break
+		}
+		s = append(s, n)
+	}
+	leap := -1
+	if len(s) == 14 {
+		// major terms between the solstices
+		var z []float64
+		for t := Dahan; t < Dongzhi; t += 2 {
+			z = append(z, civilDay(TermJDE(y, t, e)))
+		}
+		for i := 1; leap < 0 && i < 13; i++ {
+			leap = i
+			for _, d := range z {
+				if d >= s[i] && d < s[i+1] {
+					leap = -1
+					break
+				}
+			}
+		}
+	}
+	months := make([]Month, len(s)-1)
+	yr, n := y-1, 11
+	for i := range months {
+		if i > 0 && i != leap {
+			if n++; n > 12 {
+				yr, n = y, 1
+			}
+		}
+		months[i] = Month{
+			Year:   yr,
+			Number: n,
+			Leap:   i == leap,
+			Start:  s[i],
+			Days:   int(s[i+1] - s[i]),
+		}
+	}
+	return months
+}
+
+// Months returns the months of Chinese year y, the year beginning in
+// Gregorian year y.
+// 计算农历y年的各月
+//
+// Argument e must be a valid Planet object for Earth.
+//
+// The result has 12 or 13 months, from the first month, 正月, through the
+// twelfth month, 腊月, including any leap month.
+func Months(y int, e pp.Planet) (ms []Month) {
+	for _, m := range append(sui(y, e), sui(y+1, e)...) {
+		if m.Year == y {
+			ms = append(ms, m)
+		}
+	}
+	return
+}
+
+// NewYear returns the Gregorian month and day of the Chinese New Year,
+// 春节, in Gregorian year y.
+//
+// Argument e must be a valid Planet object for Earth.
+func NewYear(y int, e pp.Planet) (mon, day int) {
+	for _, m := range sui(y, e) {
+		if m.Year == y {
+			_, mon, day = gregorian(m.Start)
+			return
+		}
+	}
+	return
+}
+
+// Date is a date of the Chinese calendar.
+type Date struct {
+	Year  int  // Gregorian year in which the Chinese year begins
+	Month int  // 1 to 12
+	Leap  bool // true for a date in a leap month
+	Day   int  // 1 to 30
+}
+
+var dayNames = [30]string{
+	"初一", "初二", "初三", "初四", "初五", "初六", "初七", "初八", "初九", "初十",
+	"十一", "十二", "十三", "十四", "十五", "十六", "十七", "十八", "十九", "二十",
+	"廿一", "廿二", "廿三", "廿四", "廿五", "廿六", "廿七", "廿八", "廿九", "三十",
+}
+
+// String returns the date in Chinese with the year of the sexagenary
+// cycle, "癸卯年闰二月初一" for example.
+func (d Date) String() string {
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 || d.Day > 30 {
+		return fmt.Sprintf("Date(%d, %d, %t, %d)", d.Year, d.Month, d.Leap, d.Day)
+	}
+	return YearGanzhi(d.Year).String() + "年" +
+		Month{Number: d.Month, Leap: d.Leap}.String() + dayNames[d.Day-1]
+}
+
+// FromGregorian converts a Gregorian calendar date to a Chinese date.
+// 公历转农历
+//
+// Argument e must be a valid Planet object for Earth.
+func FromGregorian(y, m, d int, e pp.Planet) Date {
+	jd := julian.CalendarGregorianToJD(y, m, float64(d))
+	var c Date
+	for _, mo := range append(sui(y, e), sui(y+1, e)...) {
+		if jd >= mo.Start && jd < mo.Start+float64(mo.Days) {
+			c = Date{mo.Year, mo.Number, mo.Leap, int(jd-mo.Start) + 1}
+			break
+		}
+	}
+	return c
+}
+
+// Gregorian converts a Chinese date to a Gregorian calendar date.
+// 农历转公历
+//
+// Argument e must be a valid Planet object for Earth.
+//
+// ErrorInvalidDate is returned if the month does not exist in the year or
+// the day does not exist in the month.
+func (d Date) Gregorian(e pp.Planet) (y, m, day int, err error) {
+	for _, mo := range Months(d.Year, e) {
+		if mo.Number == d.Month && mo.Leap == d.Leap {
+			if d.Day < 1 || d.Day > mo.Days {
+				break
+			}
+			y, m, day = gregorian(mo.Start + float64(d.Day-1))
+			return
+		}
+	}
+	return 0, 0, 0, ErrorInvalidDate
+}
@@ -0,0 +1,138 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Chinese: The Chinese lunisolar calendar.
+// 农历
+//
+// This package is not from the book.  It computes the Chinese calendar by
+// the rules of the Shixian calendar (时宪历) of 1645 as revised for modern
+// use, with true New Moons and true solar terms (定气) computed for the
+// meridian of 120° east, China Standard Time:
+//
+// A month begins on the civil day of New Moon.  The month containing the
+// December solstice (冬至) is the eleventh month.  When there are thirteen
+// months from one eleventh month to the next, the first month of them that
+// contains no major solar term (中气) is a leap month, numbered as the
+// month before it.
+//
+// Solar terms are computed with the Planet given for Earth, as by package
+// solstice, and New Moons by package moonphase.  Full VSOP87 theory is
+// needed for the accuracy stated here; with planetposition.Keplerian solar
+// terms are off by a few minutes.  Dates are converted from dynamical
+// time with deltat.Interp10A.  Results are good where neither a New Moon
+// nor a major solar term falls within a few minutes of midnight.  For dates
+// before 1645 the rules do not match the calendars then in use.
+package chinese
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/mooncaker816/learnmeeus/v3/solstice"
+	"github.com/soniakeys/unit"
+)
+
+// Term identifies one of the 24 solar terms, 节气.
+//
+// Terms are numbered in order through the Gregorian year, from Xiaohan at
+// a solar longitude of 285° to Dongzhi at 270°.
+type Term int
+
+// The 24 solar terms.  Odd numbered terms are the major terms, 中气, at
+// multiples of 30° of solar longitude.
+const (
+	Xiaohan     Term = iota // 小寒, 285°
+	Dahan                   // 大寒, 300°
+	Lichun                  // 立春, 315°
+	Yushui                  // 雨水, 330°
+	Jingzhe                 // 惊蛰, 345°
+	Chunfen                 // 春分, 0°
+	Qingming                // 清明, 15°
+	Guyu                    // 谷雨, 30°
+	Lixia                   // 立夏, 45°
+	Xiaoman                 // 小满, 60°
+	Mangzhong               // 芒种, 75°
+	Xiazhi                  // 夏至, 90°
+	Xiaoshu                 // 小暑, 105°
+	Dashu                   // 大暑, 120°
+	Liqiu                   // 立秋, 135°
+	Chushu                  // 处暑, 150°
+	Bailu                   // 白露, 165°
+	Qiufen                  // 秋分, 180°
+	Hanlu                   // 寒露, 195°
+	Shuangjiang             // 霜降, 210°
+	Lidong                  // 立冬, 225°
+	Xiaoxue                 // 小雪, 240°
+	Daxue                   // 大雪, 255°
+	Dongzhi                 // 冬至, 270°
+)
+
+var termNames = [24]string{
+	"小寒", "大寒", "立春", "雨水", "惊蛰", "春分",
+	"清明", "谷雨", "立夏", "小满", "芒种", "夏至",
+	"小暑", "大暑", "立秋", "处暑", "白露", "秋分",
+	"寒露", "霜降", "立冬", "小雪", "大雪", "冬至",
+}
+
+// String returns the Chinese name of the term, "冬至" for example.
+func (t Term) String() string {
+	if t < Xiaohan || t > Dongzhi {
+		return fmt.Sprintf("Term(%d)", int(t))
+	}
+	return termNames[t]
+}
+
+// Major returns true for the major solar terms, 中气.
+func (t Term) Major() bool {
+	return t%2 == 1
+}
+
+// Longitude returns the apparent solar longitude of the term.
+func (t Term) Longitude() unit.Angle {
+	return unit.AngleFromDeg(float64(285 + 15*t)).Mod1()
+}
+
+// TermJDE returns the JDE of solar term t in Gregorian year y.
+// 计算y年节气t的力学时
+//
+// Argument e must be a valid Planet object for Earth.
+//
+// The result is accurate to the accuracy of e, about one second of time
+// with full VSOP87 theory, a few minutes with planetposition.Keplerian.
+func TermJDE(y int, t Term, e pp.Planet) float64 {
+	return solstice.Longitude(y, t.Longitude(), e)
+}
+
+// Terms returns JDEs of the 24 solar terms of Gregorian year y, indexed by
+// Term.
+// 计算y年的二十四节气
+//
+// Argument e must be a valid Planet object for Earth.
+func Terms(y int, e pp.Planet) (jde [24]float64) {
+	for t := range jde {
+		jde[t] = TermJDE(y, Term(t), e)
+	}
+	return
+}
+
+// cst is the offset of China Standard Time from UT, 8 hours, in days.
+const cst = 8. / 24
+
+// civilDay returns the julian day of 0h of the date in China Standard Time
+// containing jde.  The date is given as for 0h UT of the same calendar date,
+// as by julian.CalendarGregorianToJD.
+func civilDay(jde float64) float64 {
+	jd := jde - deltat.Interp10A(jde).Day() + cst
+	return math.Floor(jd-.5) + .5
+}
+
+// TermDate returns the Gregorian date in China Standard Time of solar term
+// t in year y.
+//
+// Argument e must be a valid Planet object for Earth.
+func TermDate(y int, t Term, e pp.Planet) (mon, day int) {
+	_, mon, day = gregorian(civilDay(TermJDE(y, t, e)))
+	return
+}
@@ -0,0 +1,146 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package chinese_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mooncaker816/learnmeeus/v3/chinese"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+)
+
+func ExampleTermDate() {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, t := range []chinese.Term{chinese.Lichun, chinese.Qingming, chinese.Dongzhi} {
+		m, d := chinese.TermDate(2023, t, e)
+		fmt.Println(t, m, d)
+	}
+	// Output:
+	// 立春 2 4
+	// 清明 4 5
+	// 冬至 12 22
+}
+
+func ExampleMonths() {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, m := range chinese.Months(2023, e)[:4] {
+		fmt.Println(m, julian.JDToTime(m.Start).Format("Jan 2"), m.Days)
+	}
+	// Output:
+	// 正月 Jan 22 29
+	// 二月 Feb 20 30
+	// 闰二月 Mar 22 29
+	// 三月 Apr 20 29
+}
+
+func ExampleFromGregorian() {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	d := chinese.FromGregorian(2033, 12, 22, e)
+	fmt.Println(d)
+	fmt.Println(d.Gregorian(e))
+	// Output:
+	// 癸丑年闰冬月初一
+	// 2033 12 22 <nil>
+}
+
+func ExampleDayGanzhi() {
+	jd := julian.CalendarGregorianToJD(2000, 1, 1)
+	fmt.Println(chinese.DayGanzhi(jd))
+	fmt.Println(chinese.YearGanzhi(2024), chinese.YearGanzhi(2024).Animal())
+	fmt.Println(chinese.MonthGanzhi(2024, 1))
+	// Output:
+	// 戊午
+	// 甲辰 龙
+	// 丙寅
+}
+
+func TestNewYear(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		y, mon, day int
+		leap        int // leap month, 0 for none
+	}{
+		{2017, 1, 28, 6},
+		{2018, 2, 16, 0},
+		{2019, 2, 5, 0},
+		{2020, 1, 25, 4},
+		{2021, 2, 12, 0},
+		{2022, 2, 1, 0},
+		{2023, 1, 22, 2},
+		{2024, 2, 10, 0},
+		{2025, 1, 29, 6},
+		{2026, 2, 17, 0},
+		{2028, 1, 26, 5},
+		{2031, 1, 23, 3},
+		{2033, 1, 31, 11},
+	} {
+		if mon, day := chinese.NewYear(tc.y, e); mon != tc.mon || day != tc.day {
+			t.Error(tc.y, "new year", mon, day)
+		}
+		leap := 0
+		for _, m := range chinese.Months(tc.y, e) {
+			if m.Leap {
+				leap = m.Number
+			}
+		}
+		if leap != tc.leap {
+			t.Error(tc.y, "leap month", leap)
+		}
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for m := 1; m <= 12; m++ {
+		for d := 1; d <= 28; d += 9 {
+			c := chinese.FromGregorian(2023, m, d, e)
+			y, mon, day, err := c.Gregorian(e)
+			if err != nil || y != 2023 || mon != m || day != d {
+				t.Error(m, d, c, y, mon, day, err)
+			}
+		}
+	}
+	if _, _, _, err := (chinese.Date{Year: 2024, Month: 2, Leap: true, Day: 1}).Gregorian(e); err != chinese.ErrorInvalidDate {
+		t.Error(err)
+	}
+}
+
+func TestString(t *testing.T) {
+	for _, tc := range []struct {
+		s    fmt.Stringer
+		want string
+	}{
+		{chinese.Month{Number: 2, Leap: true}, "闰二月"},
+		{chinese.Month{}, "Month(0)"},
+		{chinese.Month{Number: 13}, "Month(13)"},
+		{chinese.Date{Year: 2023, Month: 2, Leap: true, Day: 1}, "癸卯年闰二月初一"},
+		// the zero Date, as returned by FromGregorian on failure
+		{chinese.Date{}, "Date(0, 0, false, 0)"},
+		{chinese.Date{Year: 2023, Month: 1, Day: 31}, "Date(2023, 1, false, 31)"},
+	} {
+		if got := tc.s.String(); got != tc.want {
+			t.Errorf("got %s, want %s", got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package chinese
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+)
+
+// Ganzhi is a term of the sexagenary cycle, 干支, numbered from 0 for 甲子
+// through 59 for 癸亥.
+type Ganzhi int
+
+var (
+	stems    = [10]string{"甲", "乙", "丙", "丁", "戊", "己", "庚", "辛", "壬", "癸"}
+	branches = [12]string{"子", "丑", "寅", "卯", "辰", "巳", "午", "未", "申", "酉", "戌", "亥"}
+	animals  = [12]string{"鼠", "牛", "虎", "兔", "龙", "蛇", "马", "羊", "猴", "鸡", "狗", "猪"}
+)
+
+// Stem returns the heavenly stem, 天干, numbered from 0 for 甲.
+func (g Ganzhi) Stem() int {
+	return int(g) % 10
+}
+
+// Branch returns the earthly branch, 地支, numbered from 0 for 子.
+func (g Ganzhi) Branch() int {
+	return int(g) % 12
+}
+
+// String returns the Chinese name of the term, "甲子" for example.
+func (g Ganzhi) String() string {
+	if g < 0 || g > 59 {
+		return fmt.Sprintf("Ganzhi(%d)", int(g))
+	}
+	return stems[g.Stem()] + branches[g.Branch()]
+}
+
+// Animal returns the animal of the branch, 生肖, "鼠" for example.
+func (g Ganzhi) Animal() string {
+	return animals[g.Branch()]
+}
+
+// newGanzhi returns n modulo 60.
+func newGanzhi(n int) Ganzhi {
+	return Ganzhi(n - 60*base.FloorDiv(n, 60))
+}
+
+// YearGanzhi returns the term of the sexagenary cycle of Chinese year y,
+// the year beginning in Gregorian year y.
+// 年干支
+func YearGanzhi(y int) Ganzhi {
+	return newGanzhi(y - 4)
+}
+
+// MonthGanzhi returns the term of the sexagenary cycle of month m of
+// Chinese year y.
+// 月干支
+//
+// A leap month takes no term of its own.  Some almanacs give it the term
+// of the month before it.
+func MonthGanzhi(y, m int) Ganzhi {
+	// the first month of a year with stem 甲 is 丙寅
+	return newGanzhi(12*(y-4) + m + 1)
+}
+
+// DayGanzhi returns the term of the sexagenary cycle of the day containing
+// jd.
+// 日干支
+//
+// For the date in China, give jd as for 0h UT of the same calendar date, as
+// with julian.CalendarGregorianToJD.
+func DayGanzhi(jd float64) Ganzhi {
+	// 2000 January 1 is 戊午
+	return newGanzhi(int(math.Floor(jd+.5)) + 49)
+}
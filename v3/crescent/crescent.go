@@ -0,0 +1,255 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Crescent: Visibility of the young lunar crescent.
+//
+// This package is not from the book.  It computes the quantities used by
+// the crescent visibility criteria of B. D. Yallop, "A Method for
+// Predicting the First Sighting of the New Crescent Moon", NAO Technical
+// Note 69, 1997, and of M. Odeh, "New Criterion for Lunar Crescent
+// Visibility", Experimental Astronomy 18, 2004, for use with observational
+// lunar calendars.
+//
+// Following both authors, quantities are computed at the "best time",
+// sunset plus four ninths of the lag from sunset to moonset.
+package crescent
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/angle"
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/body"
+	"github.com/mooncaker816/learnmeeus/v3/coord"
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/mooncaker816/learnmeeus/v3/moonphase"
+	"github.com/mooncaker816/learnmeeus/v3/moonposition"
+	"github.com/mooncaker816/learnmeeus/v3/parallax"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/mooncaker816/learnmeeus/v3/rise"
+	"github.com/mooncaker816/learnmeeus/v3/semidiameter"
+	"github.com/mooncaker816/learnmeeus/v3/sidereal"
+	"github.com/soniakeys/unit"
+)
+
+// Errors returned when the quantities cannot be computed.
+var (
+	ErrorNoSunset  = errors.New("No sunset")
+	ErrorNoMoonset = errors.New("No moonset")
+)
+
+// Circumstances are the quantities of crescent visibility for an evening
+// at a location.
+type Circumstances struct {
+	Sunset  float64 // UT julian day of sunset
+	Moonset float64 // UT julian day of moonset, the one nearest sunset
+	Best    float64 // UT julian day of the best time
+	Lag     unit.Time
+	Age     unit.Time // time from New Moon to sunset
+
+	// Geocentric quantities at the best time, ignoring refraction.
+	ARCL unit.Angle // elongation of the Moon from the Sun
+	ARCV unit.Angle // altitude of the Moon less altitude of the Sun
+	DAZ  unit.Angle // azimuth of the Sun less azimuth of the Moon
+
+	// Topocentric quantities at the best time.
+	TopoARCL unit.Angle
+	TopoARCV unit.Angle
+	SD       unit.Angle // semidiameter of the Moon
+
+	// W is the width of the crescent from the topocentric semidiameter
+	// and the geocentric elongation, as used by Yallop.  TopoW uses the
+	// topocentric elongation, as used by Odeh.
+	W, TopoW unit.Angle
+
+	Q float64 // Yallop's q
+	V float64 // Odeh's V
+}
+
+// At computes circumstances of crescent visibility for the evening of a
+// date.
+// 计算某日傍晚新月的可见性参数
+//
+//	yr, mon, day are the Gregorian date, local to the observer.
+//	pos is geographic coordinates of observer.
+//	e must be a valid Planet object for Earth.
+//
+// Sunset is the first sunset after local mean noon, moonset the one
+// nearest sunset.  If the Moon sets before the Sun, Lag is negative and
+// other quantities are computed at sunset.
+//
+// ErrorNoSunset is returned at high latitudes when the Sun does not set
+// and ErrorNoMoonset when the Moon does not set near sunset.
+func At(yr, mon, day int, pos globe.Coord, e pp.Planet) (*Circumstances, error) {
+	// local mean noon, with longitude measured positively westward.
+	noon := julian.CalendarGregorianToJD(yr, mon, float64(day)) +
+		.5 + pos.Lon.Rad()/(2*math.Pi)
+	sun := body.Sun{Earth: e}
+	c := &Circumstances{}
+	for _, ev := range eventsOf(sun, pos, rise.Stdh0Solar, noon, noon+.5) {
+		if ev.Kind == rise.Setting {
+			c.Sunset = ev.JD
+			break
+		}
+	}
+	if c.Sunset == 0 {
+		return nil, ErrorNoSunset
+	}
+	ΔT := deltat.Interp10A(c.Sunset).Day()
+	_, _, Δ := body.Moon{}.Apparent(c.Sunset + ΔT)
+	h0 := rise.Stdh0Lunar(moonposition.Parallax(Δ * base.AU))
+	for _, ev := range eventsOf(body.Moon{}, pos, h0, c.Sunset-.5, c.Sunset+.5) {
+		if ev.Kind == rise.Setting && (c.Moonset == 0 ||
+			math.Abs(ev.JD-c.Sunset) < math.Abs(c.Moonset-c.Sunset)) {
+			c.Moonset = ev.JD
+		}
+	}
+	if c.Moonset == 0 {
+		return nil, ErrorNoMoonset
+	}
+	lag := c.Moonset - c.Sunset
+	c.Lag = unit.TimeFromDay(lag)
+	c.Best = c.Sunset
+	if lag > 0 {
+		c.Best += lag * 4 / 9
+	}
+	c.Age = unit.TimeFromDay(c.Sunset + ΔT - newMoonBefore(c.Sunset+ΔT))
+	c.compute(pos, e)
+	return c, nil
+}
+
+// eventsOf wraps rise.Events, discarding the up result.
+func eventsOf(b body.Body, pos globe.Coord, h0 unit.Angle, jd1, jd2 float64) []rise.Event {
+	ev, _ := rise.Events(b, pos, h0, jd1, jd2)
+	return ev
+}
+
+// newMoonBefore returns the jde of the last New Moon at or before jde.
+func newMoonBefore(jde float64) (n float64) {
+	it := moonphase.NewIterator(jde - 30)
+	for {
+		p := it.Next()
+		if p.JDE > jde {
+			return
+		}
+		if p.Phase == moonphase.NewMoon {
+			n = p.JDE
+		}
+	}
+}
+
+// compute fills in quantities at c.Best.
+func (c *Circumstances) compute(pos globe.Coord, e pp.Planet) {
+	jd := c.Best
+	jde := jd + deltat.Interp10A(jd).Day()
+	αs, δs, Δs := body.Sun{Earth: e}.Apparent(jde)
+	αm, δm, Δm := body.Moon{}.Apparent(jde)
+	st := sidereal.Apparent(jd)
+	Azs, hs := coord.EqToHz(αs, δs, pos.Lat, pos.Lon, st)
+	Azm, hm := coord.EqToHz(αm, δm, pos.Lat, pos.Lon, st)
+	c.ARCL = angle.Sep(αs.Angle(), δs, αm.Angle(), δm)
+	c.ARCV = hm - hs
+	c.DAZ = unit.Angle(math.Remainder((Azs - Azm).Rad(), 2*math.Pi))
+	// topocentric positions.  parallax.Topocentric takes the time for
+	// sidereal time, so is given UT.
+	ρsφʹ, ρcφʹ := globe.Earth76.ParallaxConstants(pos.Lat, 0)
+	αsʹ, δsʹ := parallax.Topocentric(αs, δs, Δs, ρsφʹ, ρcφʹ, pos.Lon, jd)
+	αmʹ, δmʹ := parallax.Topocentric(αm, δm, Δm, ρsφʹ, ρcφʹ, pos.Lon, jd)
+	_, hsʹ := coord.EqToHz(αsʹ, δsʹ, pos.Lat, pos.Lon, st)
+	_, hmʹ := coord.EqToHz(αmʹ, δmʹ, pos.Lat, pos.Lon, st)
+	c.TopoARCL = angle.Sep(αsʹ.Angle(), δsʹ, αmʹ.Angle(), δmʹ)
+	c.TopoARCV = hmʹ - hsʹ
+	c.SD = semidiameter.MoonTopocentric2(Δm, hm)
+	c.W = c.SD.Mul(1 - c.ARCL.Cos())
+	c.TopoW = c.SD.Mul(1 - c.TopoARCL.Cos())
+	// Yallop, with W in minutes and ARCV in degrees
+	w := c.W.Min()
+	c.Q = (c.ARCV.Deg() - base.Horner(w, 11.8371, -6.3226, .7319, -.1018)) / 10
+	// Odeh, with TopoW in minutes and TopoARCV in degrees
+	w = c.TopoW.Min()
+	c.V = c.TopoARCV.Deg() - base.Horner(w, 7.1651, -6.3226, .7319, -.1018)
+}
+
+// YallopZone is a visibility class of Yallop's criterion.
+type YallopZone int
+
+// Yallop's classes, from his q.
+const (
+	YallopA YallopZone = iota // easily visible, q > +.216
+	YallopB                   // visible under perfect conditions, q > -.014
+	YallopC                   // may need optical aid to find the crescent, q > -.160
+	YallopD                   // will need optical aid, q > -.232
+	YallopE                   // not visible with a telescope, q > -.293
+	YallopF                   // not visible, below the Danjon limit
+)
+
+// String returns the letter of the zone, "A" for example.
+func (z YallopZone) String() string {
+	if z < 0 || z > YallopF {
+		return fmt.Sprintf("YallopZone(%d)", int(z))
+	}
+	return string(rune('A' + z))
+}
+
+// Yallop returns the visibility class of Yallop's criterion.
+// 按 Yallop 判据的可见性分类
+//
+// The result is YallopF if the Moon sets before the Sun.
+func (c *Circumstances) Yallop() YallopZone {
+	switch {
+	case c.Lag <= 0:
+		return YallopF
+	case c.Q > .216:
+		return YallopA
+	case c.Q > -.014:
+		return YallopB
+	case c.Q > -.160:
+		return YallopC
+	case c.Q > -.232:
+		return YallopD
+	case c.Q > -.293:
+		return YallopE
+	}
+	return YallopF
+}
+
+// OdehZone is a visibility class of Odeh's criterion.
+type OdehZone int
+
+// Odeh's classes, from his V.
+const (
+	OdehA OdehZone = iota // visible by naked eye, V ≥ 5.65
+	OdehB                 // visible by optical aid, could be by naked eye, V ≥ 2
+	OdehC                 // visible by optical aid only, V ≥ -.96
+	OdehD                 // not visible even by optical aid
+)
+
+// String returns the letter of the zone, "A" for example.
+func (z OdehZone) String() string {
+	if z < 0 || z > OdehD {
+		return fmt.Sprintf("OdehZone(%d)", int(z))
+	}
+	return string(rune('A' + z))
+}
+
+// Odeh returns the visibility class of Odeh's criterion.
+// 按 Odeh 判据的可见性分类
+//
+// The result is OdehD if the Moon sets before the Sun.
+func (c *Circumstances) Odeh() OdehZone {
+	switch {
+	case c.Lag <= 0:
+		return OdehD
+	case c.V >= 5.65:
+		return OdehA
+	case c.V >= 2:
+		return OdehB
+	case c.V >= -.96:
+		return OdehC
+	}
+	return OdehD
+}
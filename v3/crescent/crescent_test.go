@@ -0,0 +1,110 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package crescent_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mooncaker816/learnmeeus/v3/crescent"
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/soniakeys/unit"
+)
+
+var mecca = globe.Coord{
+	Lat: unit.AngleFromDeg(21.42),
+	Lon: unit.AngleFromDeg(-39.83),
+}
+
+func earth(t *testing.T) pp.Planet {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func TestAt(t *testing.T) {
+	// New Moon 2023 March 21 at 17ʰ23ᵐ UT.  The crescent was easily seen
+	// from the Middle East the following evening.
+	e := earth(t)
+	c, err := crescent.At(2023, 3, 22, mecca, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(c.Age.Hour()-22.2) > .2 || math.Abs(c.Lag.Min()-51) > 2 {
+		t.Error("age, lag:", c.Age.Hour(), c.Lag.Min())
+	}
+	if c.Best <= c.Sunset || c.Best >= c.Moonset {
+		t.Error("best time:", c.Sunset, c.Best, c.Moonset)
+	}
+	if c.ARCL.Deg() < 12 || c.ARCL.Deg() > 13.5 || c.TopoARCL >= c.ARCL {
+		t.Error("ARCL:", c.ARCL.Deg(), c.TopoARCL.Deg())
+	}
+	if c.Yallop() != crescent.YallopA || c.Odeh() != crescent.OdehA {
+		t.Error("zones:", c.Q, c.Yallop(), c.V, c.Odeh())
+	}
+	// the evening before, the Moon set before the Sun.
+	c, err = crescent.At(2023, 3, 21, mecca, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Lag >= 0 || c.Best != c.Sunset ||
+		c.Yallop() != crescent.YallopF || c.Odeh() != crescent.OdehD {
+		t.Error(c.Lag.Min(), c.Yallop(), c.Odeh())
+	}
+}
+
+func TestAtWest(t *testing.T) {
+	// Sunset in Los Angeles is after 0ʰ UT of the next day.  The Moon,
+	// 8.7 hours old, is below the Danjon limit.
+	la := globe.Coord{Lat: unit.AngleFromDeg(34.05), Lon: unit.AngleFromDeg(118.25)}
+	c, err := crescent.At(2023, 3, 21, la, earth(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(c.Age.Hour()-8.7) > .2 || c.Lag <= 0 {
+		t.Error(c.Age.Hour(), c.Lag.Min())
+	}
+	if c.ARCL.Deg() > 7 || c.Yallop() != crescent.YallopF {
+		t.Error(c.ARCL.Deg(), c.Yallop())
+	}
+}
+
+func TestNoSunset(t *testing.T) {
+	p := globe.Coord{Lat: unit.AngleFromDeg(80)}
+	if _, err := crescent.At(2023, 6, 20, p, earth(t)); err != crescent.ErrorNoSunset {
+		t.Error(err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	g := crescent.NewGrid(2023, 3, 22, earth(t),
+		unit.AngleFromDeg(-60), unit.AngleFromDeg(60),
+		unit.AngleFromDeg(-120), unit.AngleFromDeg(120), unit.AngleFromDeg(30))
+	if len(g.Lat) != 5 || len(g.Lon) != 9 || len(g.C) != 5 || len(g.C[0]) != 9 {
+		t.Fatal(len(g.Lat), len(g.Lon))
+	}
+	// visibility improves to the west along the equator
+	row := g.C[2]
+	for j := 1; j < len(row); j++ {
+		if row[j].Q <= row[j-1].Q {
+			t.Error(g.Lon[j].Deg(), row[j].Q, row[j-1].Q)
+		}
+	}
+	for _, step := range []unit.Angle{0, unit.AngleFromDeg(-30)} {
+		if g := crescent.NewGrid(2023, 3, 22, earth(t), unit.AngleFromDeg(-60),
+			unit.AngleFromDeg(60), 0, 0, step); g != nil {
+			t.Error("step", step.Deg())
+		}
+	}
+}
+
+func ExampleYallopZone_String() {
+	fmt.Println(crescent.YallopB, crescent.OdehC)
+	// Output:
+	// B C
+}
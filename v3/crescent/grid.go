@@ -0,0 +1,56 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package crescent
+
+import (
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/soniakeys/unit"
+)
+
+// Grid holds circumstances of crescent visibility over a region for an
+// evening.
+//
+// C[i][j] holds circumstances for latitude Lat[i] and longitude Lon[j].
+// It is nil where At returned an error, typically at high latitudes.
+type Grid struct {
+	Lat, Lon []unit.Angle
+	C        [][]*Circumstances
+}
+
+// NewGrid computes circumstances of crescent visibility over a region for
+// the evening of a date.
+// 计算某日傍晚一个区域内各点的新月可见性
+//
+//	yr, mon, day are the Gregorian date, local to each point.
+//	e must be a valid Planet object for Earth.
+//	lat1, lat2 are the southern and northern limits of the region.
+//	lon1, lon2 are the eastern and western limits, measured positively
+//	    westward as with globe.Coord.
+//	step is the spacing of points in latitude and in longitude.
+//
+// For a global map, limits of ±60° latitude and ±180° longitude cover the
+// region where the crescent is usually first seen.
+//
+// The result is nil if step is not positive.
+func NewGrid(yr, mon, day int, e pp.Planet, lat1, lat2, lon1, lon2, step unit.Angle) *Grid {
+	if !(step > 0) {
+		return nil
+	}
+	g := &Grid{}
+	for φ := lat1; φ <= lat2+step/2; φ += step {
+		g.Lat = append(g.Lat, φ)
+	}
+	for ψ := lon1; ψ <= lon2+step/2; ψ += step {
+		g.Lon = append(g.Lon, ψ)
+	}
+	g.C = make([][]*Circumstances, len(g.Lat))
+	for i, φ := range g.Lat {
+		g.C[i] = make([]*Circumstances, len(g.Lon))
+		for j, ψ := range g.Lon {
+			g.C[i][j], _ = At(yr, mon, day, globe.Coord{Lat: φ, Lon: ψ}, e)
+		}
+	}
+	return g
+}
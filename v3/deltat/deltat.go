@@ -17,6 +17,12 @@
 // Mean Time".
 //
 // The return value for all functions is ΔT in seconds.
+//
+// Beyond the chapter, DeltaT covers the years -1999 to +3000 with a single
+// function, using Table 10.A where it applies and the polynomials of
+// Espenak and Meeus elsewhere, and giving an estimate of uncertainty.
+// A Model can also use tables of recent values loaded from IERS or USNO
+// data files with LoadFinals or LoadTable.
 package deltat
 
 import (
@@ -6,6 +6,7 @@ package deltat_test
 import (
 	"fmt"
 	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -101,3 +102,89 @@ func TestPoly1900to1997(t *testing.T) {
 		}
 	}
 }
+
+func TestEspenakMeeus(t *testing.T) {
+	// values from the canon, and continuity at ends of segments
+	for _, tp := range []struct {
+		year float64
+		ΔT   unit.Time
+	}{
+		{-500, 17190},
+		{0, 10580},
+		{1000, 1574},
+		{1900, -2.8},
+		{2000, 63.9},
+	} {
+		if ΔT := deltat.EspenakMeeus(tp.year); math.Abs((ΔT - tp.ΔT).Sec()) > math.Abs(tp.ΔT.Sec())*.002+.1 {
+			t.Errorf("%v: got %.1f", tp.year, ΔT)
+		}
+	}
+	for _, y := range []float64{-500, 500, 1600, 1700, 1800, 1860, 1900,
+		1920, 1941, 1961, 1986, 2005, 2050, 2150} {
+		a := deltat.EspenakMeeus(y - 1e-9)
+		b := deltat.EspenakMeeus(y)
+		if math.Abs((a - b).Sec()) > 1.5 {
+			t.Errorf("%v: %.2f %.2f", y, a, b)
+		}
+	}
+}
+
+func TestModel(t *testing.T) {
+	// Table 10.A within its range
+	jd := julian.CalendarGregorianToJD(1977, 2, 18)
+	if ΔT, _ := deltat.DeltaT(jd); math.Abs((ΔT - deltat.Interp10A(jd)).Sec()) > .1 {
+		t.Error(ΔT)
+	}
+	// continuous at the ends of the table
+	for _, y := range []float64{1620, 2018} {
+		a, _ := deltat.NewModel().Year(y - 1e-6)
+		b, _ := deltat.NewModel().Year(y + 1e-6)
+		if math.Abs((a - b).Sec()) > .01 {
+			t.Error(y, a, b)
+		}
+	}
+	// polynomials far from the table, with growing uncertainty
+	ΔT, σ := deltat.NewModel().Year(-1000)
+	if ΔT != deltat.EspenakMeeus(-1000) || σ != deltat.Uncertainty(-1000) {
+		t.Error(ΔT, σ)
+	}
+	if _, σ2 := deltat.NewModel().Year(-1500); σ2 <= σ {
+		t.Error(σ, σ2)
+	}
+}
+
+func TestLoadTable(t *testing.T) {
+	tab, err := deltat.LoadTable(strings.NewReader(`Header line
+ 2019  1  1  69.2201
+ 2019  2  1  69.2422
+ 2019  3  1  69.2646
+`))
+	if err != nil || len(tab.Year) != 3 || tab.Sigma != nil {
+		t.Fatal(tab, err)
+	}
+	m := deltat.NewModel(tab)
+	ΔT, σ := m.At(julian.CalendarGregorianToJD(2019, 1, 16.5))
+	if math.Abs(ΔT.Sec()-69.231) > .001 || σ != 1 {
+		t.Error(ΔT, σ)
+	}
+}
+
+func TestLoadFinals(t *testing.T) {
+	// columns 8-15 MJD, 59-68 UT1-UTC, 69-78 its error
+	line := func(mjd, dut, σ float64) string {
+		return fmt.Sprintf("%-7s%8.2f%43s%10.7f%10.7f", "19 1 1", mjd, "I", dut, σ)
+	}
+	f := strings.Join([]string{
+		line(58484, -.0397, .00001),
+		line(58485, -.0404, .00001),
+	}, "\n")
+	tab, err := deltat.LoadFinals(strings.NewReader(f), func(float64) unit.Time {
+		return 37
+	})
+	if err != nil || len(tab.Year) != 2 {
+		t.Fatal(tab, err)
+	}
+	if math.Abs(tab.ΔT[0].Sec()-69.2237) > 1e-6 || tab.Sigma[0] != .00001 {
+		t.Error(tab.ΔT[0], tab.Sigma[0])
+	}
+}
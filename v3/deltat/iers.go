@@ -0,0 +1,111 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package deltat
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/soniakeys/unit"
+)
+
+// ErrorNoData is returned by the loaders when a file has no values.
+var ErrorNoData = errors.New("No ΔT values found")
+
+// LoadTable loads a table of ΔT from text in the formats of the USNO files
+// deltat.data and historic_deltat.data.
+// 读取 USNO 格式的 ΔT 数据文件
+//
+// Lines of four fields are taken as year, month, day, and ΔT in seconds.
+// Lines of two or three fields are taken as a decimal year, ΔT, and
+// optionally its uncertainty.  Lines that do not begin with a number, such
+// as headers, are skipped.  Values must be in increasing order of date.
+func LoadTable(r io.Reader) (*Table, error) {
+	t := &Table{}
+	σ := true // all lines give uncertainties
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		f := strings.Fields(s.Text())
+		v := make([]float64, len(f))
+		var err error
+		for i := range f {
+			if v[i], err = strconv.ParseFloat(f[i], 64); err != nil {
+				break
+			}
+		}
+		if err != nil || len(f) < 2 || len(f) > 4 {
+			continue
+		}
+		switch len(f) {
+		case 4:
+			jd := julian.CalendarGregorianToJD(int(v[0]), int(v[1]), v[2])
+			t.Year = append(t.Year, decimalYear(jd))
+			t.ΔT = append(t.ΔT, unit.Time(v[3]))
+			σ = false
+		case 3:
+			t.Sigma = append(t.Sigma, unit.Time(v[2]))
+			fallthrough
+		default:
+			if len(f) == 2 {
+				σ = false
+			}
+			t.Year = append(t.Year, v[0])
+			t.ΔT = append(t.ΔT, unit.Time(v[1]))
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.Year) == 0 {
+		return nil, ErrorNoData
+	}
+	if !σ {
+		t.Sigma = nil
+	}
+	return t, nil
+}
+
+// LoadFinals loads a table of ΔT from UT1-UTC of an IERS Rapid Service
+// file in the format of finals.all or finals2000A.all.
+// 读取 IERS finals 格式的 UT1-UTC 数据，换算为 ΔT
+//
+// Argument taiUTC must return TAI-UTC, the accumulated leap seconds, at a
+// UTC julian day, as timescale.Leap.TAIminusUTC does.  ΔT is then
+// 32.184s + (TAI-UTC) - (UT1-UTC).
+//
+// Both final values and predictions are loaded.  The uncertainties given
+// in the file are loaded as Sigma.
+func LoadFinals(r io.Reader, taiUTC func(jd float64) unit.Time) (*Table, error) {
+	t := &Table{}
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		l := s.Text()
+		if len(l) < 78 {
+			continue
+		}
+		// columns 8-15 MJD, 59-68 UT1-UTC, 69-78 its error
+		mjd, err1 := strconv.ParseFloat(strings.TrimSpace(l[7:15]), 64)
+		dut, err2 := strconv.ParseFloat(strings.TrimSpace(l[58:68]), 64)
+		σ, err3 := strconv.ParseFloat(strings.TrimSpace(l[68:78]), 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		jd := mjd + base.JMod
+		t.Year = append(t.Year, decimalYear(jd))
+		t.ΔT = append(t.ΔT, 32.184+taiUTC(jd)-unit.Time(dut))
+		t.Sigma = append(t.Sigma, unit.Time(σ))
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(t.Year) == 0 {
+		return nil, ErrorNoData
+	}
+	return t, nil
+}
@@ -0,0 +1,215 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package deltat
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/soniakeys/unit"
+)
+
+// EspenakMeeus returns ΔT from the polynomial expressions of F. Espenak
+// and J. Meeus, "Five Millennium Canon of Solar Eclipses: -1999 to +3000",
+// NASA/TP-2006-214141.
+// Espenak 与 Meeus 的 ΔT 分段多项式，适用于 -1999 年至 +3000 年
+//
+// Argument year is a decimal year.  The expressions are fit to the values
+// of Morrison and Stephenson (2004) and assume a secular acceleration of
+// the Moon of -25.858″/century².  Outside the years -1999 to +3000 the
+// parabola used for the earliest and latest years continues.
+func EspenakMeeus(year float64) unit.Time {
+	y := year
+	var ΔT float64
+	switch {
+	case y < -500:
+		ΔT = base.Horner((y-1820)*.01, -20, 0, 32)
+	case y < 500:
+		ΔT = base.Horner(y*.01, 10583.6, -1014.41, 33.78311, -5.952053,
+			-.1798452, .022174192, .0090316521)
+	case y < 1600:
+		ΔT = base.Horner((y-1000)*.01, 1574.2, -556.01, 71.23472, .319781,
+			-.8503463, -.005050998, .0083572073)
+	case y < 1700:
+		ΔT = base.Horner(y-1600, 120, -.9808, -.01532, 1./7129)
+	case y < 1800:
+		ΔT = base.Horner(y-1700, 8.83, .1603, -.0059285, .00013336,
+			-1./1174000)
+	case y < 1860:
+		ΔT = base.Horner(y-1800, 13.72, -.332447, .0068612, .0041116,
+			-.00037436, .0000121272, -.0000001699, .000000000875)
+	case y < 1900:
+		ΔT = base.Horner(y-1860, 7.62, .5737, -.251754, .01680668,
+			-.0004473624, 1./233174)
+	case y < 1920:
+		ΔT = base.Horner(y-1900, -2.79, 1.494119, -.0598939, .0061966,
+			-.000197)
+	case y < 1941:
+		ΔT = base.Horner(y-1920, 21.20, .84493, -.076100, .0020936)
+	case y < 1961:
+		ΔT = base.Horner(y-1950, 29.07, .407, -1./233, 1./2547)
+	case y < 1986:
+		ΔT = base.Horner(y-1975, 45.45, 1.067, -1./260, -1./718)
+	case y < 2005:
+		ΔT = base.Horner(y-2000, 63.86, .3345, -.060374, .0017275,
+			.000651814, .00002373599)
+	case y < 2050:
+		ΔT = base.Horner(y-2000, 62.92, .32217, .005589)
+	case y < 2150:
+		ΔT = base.Horner((y-1820)*.01, -20, 0, 32) - .5628*(2150-y)
+	default:
+		ΔT = base.Horner((y-1820)*.01, -20, 0, 32)
+	}
+	return unit.Time(ΔT)
+}
+
+// Uncertainty returns an estimate of the uncertainty of ΔT from the
+// polynomials of EspenakMeeus.
+// ΔT 的不确定度估计
+//
+// The estimate is the parabola 0.8 u² seconds, u in centuries from 1820,
+// given by Morrison and Stephenson (2004) for the uncertainty from tidal
+// braking and decade fluctuations, but not less than 1 second for the fit
+// of the polynomials.  It applies to the future as well as the past.
+func Uncertainty(year float64) unit.Time {
+	u := (year - 1820) * .01
+	return unit.Time(math.Max(.8*u*u, 1))
+}
+
+// Table is a table of ΔT values, as loaded from IERS or USNO data files.
+type Table struct {
+	Year []float64   // decimal years, increasing
+	ΔT   []unit.Time // ΔT at the years of Year
+
+	// Sigma holds uncertainties of the values of ΔT.  It may be nil,
+	// in which case an uncertainty of 1 second is used.
+	Sigma []unit.Time
+}
+
+// Table10A returns Table 10.A, p. 79, as a Table.
+//
+// Values are given for the beginning of each even year.
+func Table10A() *Table {
+	t := &Table{}
+	for i, ΔT := range table10A {
+		t.Year = append(t.Year, tableYear1+2*float64(i))
+		t.ΔT = append(t.ΔT, unit.Time(ΔT))
+	}
+	return t
+}
+
+// covers reports whether year is within the range of the table.
+func (t *Table) covers(year float64) bool {
+	n := len(t.Year)
+	return n > 0 && year >= t.Year[0] && year <= t.Year[n-1]
+}
+
+// at returns ΔT and its uncertainty by linear interpolation of the table
+// at a year within its range.
+func (t *Table) at(year float64) (ΔT, σ unit.Time) {
+	i := sort.SearchFloat64s(t.Year, year)
+	if i == 0 {
+		i = 1
+	}
+	if len(t.Year) == 1 {
+		return t.ΔT[0], t.sigma(0)
+	}
+	x1, x2 := t.Year[i-1], t.Year[i]
+	f := (year - x1) / (x2 - x1)
+	ΔT = t.ΔT[i-1] + (t.ΔT[i]-t.ΔT[i-1])*unit.Time(f)
+	σ = t.sigma(i-1) + (t.sigma(i)-t.sigma(i-1))*unit.Time(f)
+	return
+}
+
+// sigma returns the uncertainty of entry i.
+func (t *Table) sigma(i int) unit.Time {
+	if t.Sigma == nil {
+		return 1
+	}
+	return t.Sigma[i]
+}
+
+// Model computes ΔT from tables where available, and from EspenakMeeus
+// elsewhere.
+// ΔT 模型：有表处插值，无表处用多项式外推
+//
+// Within Blend years of the end of a table, the difference between the
+// table and the polynomials at its end is carried into the polynomial
+// value, decreasing linearly to zero, so that ΔT is continuous.  Tables
+// are searched in order, so that a table of recent IERS values placed
+// first takes precedence over Table 10.A.
+type Model struct {
+	Tables []*Table
+	Blend  float64 // years, 100 if zero
+}
+
+// NewModel returns a Model of the given tables, followed by Table 10.A.
+func NewModel(tables ...*Table) *Model {
+	return &Model{Tables: append(tables, Table10A())}
+}
+
+// defaultModel is the Model of function DeltaT.
+var defaultModel = NewModel()
+
+// DeltaT returns ΔT and an estimate of its uncertainty at jde, from Table
+// 10.A for the years 1620 to 2018 and from EspenakMeeus elsewhere, over
+// the years -1999 to +3000.
+// 计算 -1999 年至 +3000 年的 ΔT 及其不确定度
+//
+// For other tables, see Model.
+func DeltaT(jde float64) (ΔT, σ unit.Time) {
+	return defaultModel.At(jde)
+}
+
+// decimalYear returns the Gregorian decimal year of jde.
+func decimalYear(jde float64) float64 {
+	return 2000 + (jde-base.J2000)/365.2425
+}
+
+// At returns ΔT and an estimate of its uncertainty at jde.
+func (m *Model) At(jde float64) (ΔT, σ unit.Time) {
+	return m.Year(decimalYear(jde))
+}
+
+// Year returns ΔT and an estimate of its uncertainty for a decimal year.
+func (m *Model) Year(year float64) (ΔT, σ unit.Time) {
+	for _, t := range m.Tables {
+		if t.covers(year) {
+			return t.at(year)
+		}
+	}
+	ΔT = EspenakMeeus(year)
+	σ = Uncertainty(year)
+	blend := m.Blend
+	if blend == 0 {
+		blend = 100
+	}
+	// nearest table end
+	var end float64
+	var tab *Table
+	for _, t := range m.Tables {
+		if len(t.Year) == 0 {
+			continue
+		}
+		e := t.Year[0]
+		if year > e {
+			e = t.Year[len(t.Year)-1]
+		}
+		if tab == nil || math.Abs(year-e) < math.Abs(year-end) {
+			end, tab = e, t
+		}
+	}
+	if tab == nil {
+		return
+	}
+	f := 1 - math.Abs(year-end)/blend
+	if f <= 0 {
+		return
+	}
+	ΔTend, σend := tab.at(end)
+	ΔT += (ΔTend - EspenakMeeus(end)) * unit.Time(f)
+	σ = σ + (σend-σ)*unit.Time(f)
+	return
+}
@@ -0,0 +1,441 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/body"
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/mooncaker816/learnmeeus/v3/sidereal"
+	"github.com/soniakeys/unit"
+)
+
+// ErrorNoEclipse is returned when there is no eclipse near the date of
+// interest.
+var ErrorNoEclipse = errors.New("No eclipse")
+
+// ErrorNoLocalEclipse is returned when an eclipse is not seen from the
+// location of interest.
+var ErrorNoLocalEclipse = errors.New("No eclipse at location")
+
+// Radii used for the shadow cones, in units of the equatorial radius of
+// the Earth.
+const (
+	kPenumbra = .2725076 // Moon, for the penumbral cone
+	kUmbra    = .272281  // Moon, for the umbral cone, adjusted for limb profile
+)
+
+// rSun is the radius of the Sun in units of the equatorial radius of the
+// Earth, for a semidiameter of 959.63″ at 1 AU.
+var rSun = base.AU * math.Tan(unit.AngleFromSec(959.63).Rad()) /
+	globe.Earth76.Er
+
+// Besselian holds Besselian elements of a solar eclipse.
+// 日食的贝塞尔根数
+//
+// Elements are given as cubic polynomials in t, the time in hours from the
+// reference time T0.  Index i of each array is the coefficient of tⁱ.
+//
+// X, Y are coordinates of the shadow axis in the fundamental plane, the
+// plane through the center of the Earth perpendicular to the axis.
+// D is the declination of the axis, Mu its Greenwich hour angle.  L1, L2
+// are radii of the penumbral and umbral cones in the fundamental plane.
+// L2 is negative for a total eclipse.  F1, F2 are the semi-vertex angles
+// of the cones, constant for the duration of the eclipse.
+//
+// Distances are in units of the equatorial radius of the Earth.
+type Besselian struct {
+	T0     float64   // reference time, jde
+	ΔT     unit.Time // ΔT used to compute Mu
+	X, Y   [4]float64
+	D, Mu  [4]unit.Angle
+	L1, L2 [4]float64
+	F1, F2 unit.Angle
+}
+
+// SolarBesselian returns Besselian elements for the solar eclipse nearest
+// a date.
+//
+// Argument year is a decimal year specifying a date, as for Solar.
+// Argument e must be a valid Planet object for Earth.
+//
+// ErrorNoEclipse is returned if Solar finds no eclipse.
+func SolarBesselian(year float64, e pp.Planet) (*Besselian, error) {
+	t, _, jmax, _, _, _, _ := Solar(year)
+	if t == None {
+		return nil, ErrorNoEclipse
+	}
+	return BesselianAt(jmax, e), nil
+}
+
+// BesselianAt computes Besselian elements for a solar eclipse with
+// maximum near a given time.
+//
+// Argument jmax is a jde near the time of maximum eclipse.  Argument e must
+// be a valid Planet object for Earth.
+//
+// T0 is jmax rounded to the nearest hour.  Elements are computed from
+// apparent positions of the Sun and Moon each hour from T0-3ʰ to T0+3ʰ and
+// fit with cubic polynomials.  With positions of the Moon from package
+// moonposition, the shadow is placed to within a few tens of km.
+func BesselianAt(jmax float64, e pp.Planet) *Besselian {
+	b := &Besselian{T0: math.Floor(jmax*24+.5) / 24}
+	b.ΔT = deltat.Interp10A(b.T0)
+	var t, x, y, d, l1, l2, μ [7]float64
+	var f1, f2 unit.Angle
+	for i := range t {
+		t[i] = float64(i - 3)
+		var di, μi unit.Angle
+		x[i], y[i], di, l1[i], l2[i], μi, f1, f2 =
+			besselian(b.T0+t[i]/24, b.ΔT, e)
+		if i == 3 {
+			b.F1, b.F2 = f1, f2
+		}
+		d[i] = di.Rad()
+		μ[i] = μi.Rad()
+		if i > 0 {
+			// keep μ continuous
+			μ[i] = μ[i-1] + math.Remainder(μ[i]-μ[i-1], 2*math.Pi)
+		}
+	}
+	b.X = fitCubic(t[:], x[:])
+	b.Y = fitCubic(t[:], y[:])
+	b.L1 = fitCubic(t[:], l1[:])
+	b.L2 = fitCubic(t[:], l2[:])
+	for i, c := range fitCubic(t[:], d[:]) {
+		b.D[i] = unit.Angle(c)
+	}
+	for i, c := range fitCubic(t[:], μ[:]) {
+		b.Mu[i] = unit.Angle(c)
+	}
+	b.Mu[0] = b.Mu[0].Mod1()
+	return b
+}
+
+// besselian computes Besselian elements at jde from apparent positions of
+// the Sun and Moon.
+func besselian(jde float64, ΔT unit.Time, e pp.Planet) (x, y float64, d unit.Angle, l1, l2 float64, μ, f1, f2 unit.Angle) {
+	er := globe.Earth76.Er
+	αs, δs, rs := body.Sun{Earth: e}.Apparent(jde)
+	αm, δm, rm := body.Moon{}.Apparent(jde)
+	rs *= base.AU / er
+	rm *= base.AU / er
+	// vector from the Moon to the Sun gives direction of the shadow axis
+	sδs, cδs := δs.Sincos()
+	sαs, cαs := αs.Sincos()
+	sδm, cδm := δm.Sincos()
+	sαm, cαm := αm.Sincos()
+	gx := rs*cδs*cαs - rm*cδm*cαm
+	gy := rs*cδs*sαs - rm*cδm*sαm
+	gz := rs*sδs - rm*sδm
+	g := math.Sqrt(gx*gx + gy*gy + gz*gz)
+	a := unit.RAFromRad(math.Atan2(gy, gx))
+	d = unit.Angle(math.Asin(gz / g))
+	// Moon in the fundamental plane
+	sd, cd := d.Sincos()
+	sΔα, cΔα := (αm - a).Sincos()
+	x = rm * cδm * sΔα
+	y = rm * (sδm*cd - cδm*sd*cΔα)
+	z := rm * (sδm*sd + cδm*cd*cΔα)
+	// shadow cones
+	f1 = unit.Angle(math.Asin((rSun + kPenumbra) / g))
+	f2 = unit.Angle(math.Asin((rSun - kUmbra) / g))
+	l1 = z*f1.Tan() + kPenumbra/f1.Cos()
+	l2 = z*f2.Tan() - kUmbra/f2.Cos()
+	st := sidereal.Apparent(jde - ΔT.Day())
+	μ = (st.Angle() - a.Angle()).Mod1()
+	return
+}
+
+// fitCubic returns coefficients of the least squares cubic through points
+// x, y.
+func fitCubic(x, y []float64) (c [4]float64) {
+	// normal equations, solved by Gaussian elimination
+	var m [4][5]float64
+	for i := range x {
+		var p [4]float64
+		p[0] = 1
+		for j := 1; j < 4; j++ {
+			p[j] = p[j-1] * x[i]
+		}
+		for r := 0; r < 4; r++ {
+			for k := 0; k < 4; k++ {
+				m[r][k] += p[r] * p[k]
+			}
+			m[r][4] += p[r] * y[i]
+		}
+	}
+	for r := 0; r < 4; r++ {
+		for s := r + 1; s < 4; s++ {
+			f := m[s][r] / m[r][r]
+			for k := r; k < 5; k++ {
+				m[s][k] -= f * m[r][k]
+			}
+		}
+	}
+	for r := 3; r >= 0; r-- {
+		s := m[r][4]
+		for k := r + 1; k < 4; k++ {
+			s -= m[r][k] * c[k]
+		}
+		c[r] = s / m[r][r]
+	}
+	return
+}
+
+// poly evaluates a cubic and its derivative at t.
+func poly(c [4]float64, t float64) (v, dv float64) {
+	return base.Horner(t, c[:]...), base.Horner(t, c[1], 2*c[2], 3*c[3])
+}
+
+// polyAngle evaluates a cubic in angles and its derivative at t.
+func polyAngle(c [4]unit.Angle, t float64) (v, dv unit.Angle) {
+	f, df := poly([4]float64{c[0].Rad(), c[1].Rad(), c[2].Rad(), c[3].Rad()}, t)
+	return unit.Angle(f), unit.Angle(df)
+}
+
+// At returns Besselian elements at time t, in hours from T0.
+func (b *Besselian) At(t float64) (x, y float64, d unit.Angle, l1, l2 float64, μ unit.Angle) {
+	x, _ = poly(b.X, t)
+	y, _ = poly(b.Y, t)
+	d, _ = polyAngle(b.D, t)
+	l1, _ = poly(b.L1, t)
+	l2, _ = poly(b.L2, t)
+	μ, _ = polyAngle(b.Mu, t)
+	return x, y, d, l1, l2, μ.Mod1()
+}
+
+// observer holds values of the shadow relative to an observer at an
+// instant, following the Explanatory Supplement, section 8.3.
+type observer struct {
+	u, v   float64 // position of the shadow axis relative to the observer
+	a, b   float64 // rates of u, v per hour
+	n      float64 // √(a²+b²)
+	L1, L2 float64 // radii of the shadows in the plane of the observer
+	ξ, η   float64
+	ζ      float64
+}
+
+// observe computes shadow quantities at time t, in hours from T0, for an
+// observer with parallax constants ρs = ρ sin φ′ and ρc = ρ cos φ′.
+func (b *Besselian) observe(t float64, lon unit.Angle, ρs, ρc float64) (o observer) {
+	x, dx := poly(b.X, t)
+	y, dy := poly(b.Y, t)
+	d, dd := polyAngle(b.D, t)
+	μ, dμ := polyAngle(b.Mu, t)
+	l1, _ := poly(b.L1, t)
+	l2, _ := poly(b.L2, t)
+	// longitude is measured positively westward
+	H := μ - lon
+	sH, cH := H.Sincos()
+	sd, cd := d.Sincos()
+	o.ξ = ρc * sH
+	o.η = ρs*cd - ρc*cH*sd
+	o.ζ = ρs*sd + ρc*cH*cd
+	dξ := dμ.Rad() * ρc * cH
+	dη := dμ.Rad()*o.ξ*sd - o.ζ*dd.Rad()
+	o.u = x - o.ξ
+	o.v = y - o.η
+	o.a = dx - dξ
+	o.b = dy - dη
+	o.n = math.Hypot(o.a, o.b)
+	o.L1 = l1 - o.ζ*b.F1.Tan()
+	o.L2 = l2 - o.ζ*b.F2.Tan()
+	return
+}
+
+// SolarLocal holds local circumstances of a solar eclipse.
+//
+// Times are jde.  Position angles P are measured from the north point of
+// the Sun's limb toward the east, vertex angles V from the point of the
+// limb nearest the zenith.
+type SolarLocal struct {
+	Type        Kind    `json:"type"`        // Partial, Annular, or Total, as seen from the location
+	C1          float64 `json:"c1"`          // first contact
+	C2          float64 `json:"c2"`          // second contact, zero for a partial eclipse
+	C3          float64 `json:"c3"`          // third contact, zero for a partial eclipse
+	C4          float64 `json:"c4"`          // last contact
+	Max         float64 `json:"max"`         // maximum eclipse
+	Mag         float64 `json:"mag"`         // magnitude, fraction of the Sun's diameter covered
+	Obscuration float64 `json:"obscuration"` // fraction of the Sun's area covered
+
+	// position angles of the contacts
+	P1 unit.Angle `json:"p1"`
+	P2 unit.Angle `json:"p2"`
+	P3 unit.Angle `json:"p3"`
+	P4 unit.Angle `json:"p4"`
+	// vertex angles of the contacts
+	V1 unit.Angle `json:"v1"`
+	V2 unit.Angle `json:"v2"`
+	V3 unit.Angle `json:"v3"`
+	V4 unit.Angle `json:"v4"`
+
+	// Alt is the altitude of the Sun at maximum, neglecting refraction.
+	// The eclipse, or part of it, is not visible if the Sun is below the
+	// horizon.
+	Alt unit.Angle `json:"alt"`
+}
+
+// Local computes local circumstances of a solar eclipse.
+// 计算日食的地方情况
+//
+// Argument g is the observer location, h the height above the ellipsoid
+// in meters.
+//
+// Circumstances are computed geometrically, regardless of whether the Sun
+// is above the horizon.  ErrorNoLocalEclipse is returned if the penumbra
+// does not reach the location.
+func (b *Besselian) Local(g globe.Coord, h float64) (*SolarLocal, error) {
+	ρs, ρc := globe.Earth76.ParallaxConstants(g.Lat, h)
+	obs := func(t float64) observer { return b.observe(t, g.Lon, ρs, ρc) }
+	// time of maximum
+	t := 0.
+	var o observer
+	for i := 0; i < 50; i++ {
+		o = obs(t)
+		τ := -(o.u*o.a + o.v*o.b) / (o.n * o.n)
+		t += τ
+		if math.Abs(τ) < 1e-7 {
+			break
+		}
+	}
+	o = obs(t)
+	m := math.Hypot(o.u, o.v)
+	if m > o.L1 {
+		return nil, ErrorNoLocalEclipse
+	}
+	l := &SolarLocal{Max: b.T0 + t/24}
+	l.Alt = unit.Angle(math.Asin(o.ζ / math.Sqrt(o.ξ*o.ξ+o.η*o.η+o.ζ*o.ζ)))
+	l.Mag = (o.L1 - m) / (o.L1 + o.L2)
+	rs := (o.L1 + o.L2) / 2 // radius of the Sun
+	rm := (o.L1 - o.L2) / 2 // radius of the Moon
+	l.Obscuration = obscuration(rs, rm, m)
+	switch {
+	case m > math.Abs(o.L2):
+		l.Type = Partial
+	case o.L2 < 0:
+		l.Type = Total
+	default:
+		l.Type = Annular
+	}
+	// contacts
+	var err error
+	if l.C1, l.P1, l.V1, err = b.contact(obs, t, -1, false); err != nil {
+		return nil, err
+	}
+	if l.C4, l.P4, l.V4, err = b.contact(obs, t, 1, false); err != nil {
+		return nil, err
+	}
+	if l.Type != Partial {
+		if l.C2, l.P2, l.V2, err = b.contact(obs, t, -1, true); err != nil {
+			return nil, err
+		}
+		if l.C3, l.P3, l.V3, err = b.contact(obs, t, 1, true); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// contact finds a contact time by iteration from time of maximum tm.
+//
+// Sign s is -1 for the contact before maximum, 1 for the contact after.
+// Internal is true for the internal contacts C2 and C3.
+func (b *Besselian) contact(obs func(float64) observer, tm, s float64, internal bool) (jde float64, P, V unit.Angle, err error) {
+	t := tm
+	var o observer
+	for i := 0; i < 50; i++ {
+		o = obs(t)
+		L := o.L1
+		if internal {
+			L = math.Abs(o.L2)
+		}
+		S := (o.a*o.v - o.u*o.b) / (o.n * L)
+		if math.Abs(S) > 1 {
+			return 0, 0, 0, ErrorNoLocalEclipse
+		}
+		τ := -(o.u*o.a+o.v*o.b)/(o.n*o.n) + s*L/o.n*math.Sqrt(1-S*S)
+		t += τ
+		if math.Abs(τ) < 1e-7 {
+			break
+		}
+	}
+	o = obs(t)
+	u, v := o.u, o.v
+	if internal && o.L2 < 0 {
+		// total: the point of contact is opposite the direction of the
+		// Moon's center.
+		u, v = -u, -v
+	}
+	P = unit.Angle(math.Atan2(u, v)).Mod1()
+	q := unit.Angle(math.Atan2(o.ξ, o.η))
+	V = (P - q).Mod1()
+	return b.T0 + t/24, P, V, nil
+}
+
+// obscuration returns the fraction of the area of a disk of radius rs
+// covered by a disk of radius rm at distance m.
+func obscuration(rs, rm, m float64) float64 {
+	switch {
+	case m >= rs+rm:
+		return 0
+	case m <= math.Abs(rm-rs):
+		if rm >= rs {
+			return 1
+		}
+		return rm * rm / (rs * rs)
+	}
+	// area of the lens formed by the two circles
+	c1 := math.Acos((m*m + rs*rs - rm*rm) / (2 * m * rs))
+	c2 := math.Acos((m*m + rm*rm - rs*rs) / (2 * m * rm))
+	a := rs*rs*(c1-math.Sin(2*c1)/2) + rm*rm*(c2-math.Sin(2*c2)/2)
+	return a / (math.Pi * rs * rs)
+}
+
+// LunarContacts holds contact times of a lunar eclipse as jde.
+//
+// Times of phases that do not occur are zero.
+type LunarContacts struct {
+	P1  float64 // beginning of the penumbral eclipse
+	U1  float64 // beginning of the partial eclipse
+	U2  float64 // beginning of totality
+	Max float64 // maximum eclipse
+	U3  float64 // end of totality
+	U4  float64 // end of the partial eclipse
+	P4  float64 // end of the penumbral eclipse
+}
+
+// LunarContactTimes returns contact times of the lunar eclipse nearest a
+// date.
+// 月食各阶段的开始和结束时间
+//
+// Argument year is a decimal year specifying a date, as for Lunar.
+//
+// Times are computed from the time of maximum and the semidurations
+// returned by Lunar.  If eclipseType is None, contacts are all zero.
+func LunarContactTimes(year float64) (eclipseType int, c LunarContacts) {
+	var jmax float64
+	var sdTotal, sdPartial, sdPenumbral unit.Time
+	eclipseType, jmax, _, _, _, _, sdTotal, sdPartial, sdPenumbral = Lunar(year)
+	if eclipseType == None {
+		return
+	}
+	c.Max = jmax
+	c.P1 = jmax - sdPenumbral.Day()
+	c.P4 = jmax + sdPenumbral.Day()
+	if eclipseType >= Umbral {
+		c.U1 = jmax - sdPartial.Day()
+		c.U4 = jmax + sdPartial.Day()
+	}
+	if eclipseType == Total {
+		c.U2 = jmax - sdTotal.Day()
+		c.U3 = jmax + sdTotal.Day()
+	}
+	return
+}
@@ -0,0 +1,198 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"math"
+
+	"github.com/soniakeys/unit"
+)
+
+// SolarEclipse holds the results of Solar for one eclipse, with its saros
+// and inex numbers.
+//
+// Field tags give lower case names for encoding/json.
+type SolarEclipse struct {
+	K       float64 `json:"k"`       // lunation number k of (49.2), an integer for New Moon
+	Type    Kind    `json:"type"`    // None, Partial, Annular, AnnularTotal, or Total
+	Central bool    `json:"central"` // true if the center of the shadow touches the Earth
+	JMax    float64 `json:"jmax"`    // jde of greatest eclipse
+	Gamma   float64 `json:"gamma"`   // γ, least distance of the shadow axis from the Earth center
+	U       float64 `json:"u"`       // radius of the umbral cone in the plane of the Earth
+	P       float64 `json:"p"`       // radius of the penumbral cone
+	Mag     float64 `json:"mag"`     // magnitude, for partial eclipses only
+
+	Saros       int `json:"saros"`       // saros series
+	SarosMember int `json:"sarosMember"` // member number within the saros series, from 1
+	Inex        int `json:"inex"`        // inex series
+}
+
+// LunarEclipse holds the results of Lunar for one eclipse, with its saros
+// and inex numbers.
+//
+// Field tags give lower case names for encoding/json.  Semidurations are
+// encoded as seconds.
+type LunarEclipse struct {
+	K     float64 `json:"k"`     // lunation number k of (49.2), an integer + .5 for Full Moon
+	Type  Kind    `json:"type"`  // None, Penumbral, Umbral, or Total
+	JMax  float64 `json:"jmax"`  // jde of greatest eclipse
+	Gamma float64 `json:"gamma"` // γ, least distance of the Moon center from the shadow axis
+	Rho   float64 `json:"rho"`   // ρ, radius of the penumbral cone in the plane of the Moon
+	Sigma float64 `json:"sigma"` // σ, radius of the umbral cone
+	Mag   float64 `json:"mag"`   // magnitude
+
+	SdTotal     unit.Time `json:"sdTotal"`     // semiduration of the total phase
+	SdPartial   unit.Time `json:"sdPartial"`   // semiduration of the umbral phase
+	SdPenumbral unit.Time `json:"sdPenumbral"` // semiduration of the penumbral phase
+
+	Saros       int `json:"saros"`       // saros series
+	SarosMember int `json:"sarosMember"` // member number within the saros series, from 1
+	Inex        int `json:"inex"`        // inex series
+}
+
+// Eclipses of a saros series are 223 lunations apart, those of an inex
+// series 358 lunations apart.
+const (
+	saros = 223
+	inex  = 358
+)
+
+// firstK returns the integer lunation number of the first mean New Moon
+// at or after jde, less one to allow for the difference between mean and
+// true phases.
+func firstK(jde float64) float64 {
+	return math.Ceil((jde-2451550.09766)/29.530588861) - 1
+}
+
+// SolarEclipses returns all solar eclipses with greatest eclipse from jde1
+// up to jde2.
+// 计算时间段内的所有日食
+//
+// Eclipses are found by evaluating Solar at each New Moon in the interval
+// and are returned in order of time.
+//
+// Saros series are numbered following van den Bergh as in the NASA canons,
+// so the eclipse of 2017 August 21, for example, is member 22 of saros 145.
+// Members are counted back through the series with the same test for an
+// eclipse used by Solar.  As this test is approximate, a member number may
+// be off by one where the first members of a series are marginal partial
+// eclipses.
+//
+// Inex series are numbered from an arbitrary origin, such that the lunation
+// number k of an eclipse is 358·Saros + 223·Inex − 62842, and the eclipse of
+// 2017 August 21 is in inex series 50.  Consecutive eclipses of a saros
+// series are in consecutive inex series; consecutive eclipses of an inex
+// series are in consecutive saros series.
+func SolarEclipses(jde1, jde2 float64) (r []SolarEclipse) {
+	for k := firstK(jde1); ; k++ {
+		e := solarEclipse(k)
+		if e.JMax >= jde2 {
+			return
+		}
+		if e.Type != None && e.JMax >= jde1 {
+			r = append(r, e)
+		}
+	}
+}
+
+// SolarEclipseNear returns the results of Solar as a SolarEclipse.
+// 日食计算，结果以结构体返回
+//
+// Argument year is a decimal year specifying a date.  If e.Type is None,
+// there is no eclipse and other fields but K and JMax are not meaningful.
+// JMax is then the time of the New Moon, computed as for an eclipse.
+func SolarEclipseNear(year float64) (e SolarEclipse) {
+	return solarEclipse(snap(year, 0))
+}
+
+func solarEclipse(k float64) (e SolarEclipse) {
+	var t int
+	t, e.Central, e.JMax, e.Gamma, e.U, e.P, e.Mag = solar(k)
+	e.K, e.Type = k, Kind(t)
+	if t == None {
+		return
+	}
+	e.Saros = sarosNumber(112+38*k, 136+k/387)
+	e.SarosMember = member(k, func(k float64) bool {
+		t, _, _, _, _, _, _ := solar(k)
+		return t != None
+	})
+	e.Inex = int(k-inex*float64(e.Saros)+62842) / saros
+	return
+}
+
+// LunarEclipses returns all lunar eclipses with greatest eclipse from jde1
+// up to jde2.
+// 计算时间段内的所有月食
+//
+// Eclipses are found by evaluating Lunar at each Full Moon in the interval
+// and are returned in order of time.
+//
+// Saros and inex numbers are as described for SolarEclipses.  Lunar saros
+// series are numbered following van den Bergh, so the eclipse of 2022
+// November 8 is member 20 of saros 136.  The first members of lunar series
+// are faint penumbral eclipses, so member numbers here are more often off
+// by one than for solar eclipses.  Inex series are numbered such that
+// k − .5 = 358·Saros + 223·Inex − 58887, which puts the eclipse of 2019
+// January 21 in inex series 50.
+func LunarEclipses(jde1, jde2 float64) (r []LunarEclipse) {
+	for k := firstK(jde1) + .5; ; k++ {
+		e := lunarEclipse(k)
+		if e.JMax >= jde2 {
+			return
+		}
+		if e.Type != None && e.JMax >= jde1 {
+			r = append(r, e)
+		}
+	}
+}
+
+// LunarEclipseNear returns the results of Lunar as a LunarEclipse.
+// 月食计算，结果以结构体返回
+//
+// Argument year is a decimal year specifying a date.  If e.Type is None,
+// there is no eclipse and other fields but K and JMax are not meaningful.
+// JMax is then the time of the Full Moon, computed as for an eclipse.
+func LunarEclipseNear(year float64) (e LunarEclipse) {
+	return lunarEclipse(snap(year, .5))
+}
+
+func lunarEclipse(k float64) (e LunarEclipse) {
+	var t int
+	t, e.JMax, e.Gamma, e.Rho, e.Sigma, e.Mag,
+		e.SdTotal, e.SdPartial, e.SdPenumbral = lunar(k)
+	e.K, e.Type = k, Kind(t)
+	if t == None {
+		return
+	}
+	e.Saros = sarosNumber(124+38*(k-.5), 128+k/387)
+	e.SarosMember = member(k, func(k float64) bool {
+		t, _, _, _, _, _, _, _, _ := lunar(k)
+		return t != None
+	})
+	e.Inex = int(k-.5-inex*float64(e.Saros)+58887) / saros
+	return
+}
+
+// sarosNumber resolves saros number s, known modulo 223, to the series
+// nearest c.
+//
+// Series begin about every 31 years, or 387 lunations, and last 1200 to
+// 1500 years, so c estimates the number of a series in the middle of its
+// life.  The estimate need only be good to ±111 series, making the result
+// valid for many millennia either side of the year 2000.
+func sarosNumber(s, c float64) int {
+	s = math.Mod(s, saros)
+	return int(s + saros*math.Floor((c-s)/saros+.5))
+}
+
+// member counts back through the saros series of the eclipse at lunation k
+// while eclipse returns true.
+func member(k float64, eclipse func(k float64) bool) int {
+	n := 1
+	for eclipse(k - saros*float64(n)) {
+		n++
+	}
+	return n
+}
@@ -2,13 +2,22 @@
 // License: MIT
 
 // Eclipse: Chapter 54, Eclipses.
+//
+// Beyond the chapter, Besselian elements of solar eclipses are computed
+// from positions of the Sun and Moon, and used for local circumstances
+// following the Explanatory Supplement to the Astronomical Almanac.
+// The same elements give the central line, path limits, and greatest
+// eclipse of central eclipses on an ellipsoidal Earth.
+//
+// SolarEclipses and LunarEclipses list the eclipses of a range of dates
+// with their saros and inex numbers, for catalogs.
 package eclipse
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/mooncaker816/learnmeeus/v3/base"
-	"github.com/mooncaker816/learnmeeus/v3/moonphase"
 	"github.com/soniakeys/unit"
 )
 
@@ -18,10 +27,6 @@ func g(k, jm, c1, c2 float64) (eclipse bool, jmax, γ, u, Mʹ float64) {
 	T := k * ck
 	F := base.Horner(T, 160.7108*p, 390.67050284*p/ck,
 		-.0016118*p, -.00000227*p, .000000011*p)
-	if math.Abs(math.Sin(F)) > .36 {
-		return // no eclipse
-	}
-	eclipse = true
 	E := base.Horner(T, 1, -.002516, -.0000074)
 	M := base.Horner(T, 2.5534*p, 29.1053567*p/ck,
 		-.0000014*p, -.00000011*p)
@@ -50,6 +55,10 @@ func g(k, jm, c1, c2 float64) (eclipse bool, jmax, γ, u, Mʹ float64) {
 		-.0002*math.Sin(M-2*F1)*E +
 		-.0002*math.Sin(2*Mʹ-M)*E +
 		-.0002*sΩ
+	if math.Abs(math.Sin(F)) > .36 {
+		return // no eclipse
+	}
+	eclipse = true
 	P := .207*math.Sin(M)*E +
 		.0024*math.Sin(2*M)*E +
 		-.0392*math.Sin(Mʹ) +
@@ -85,6 +94,44 @@ const (
 	Total        // solar or lunar 全食
 )
 
+// Kind is an eclipse type, one of the constants None through Total.
+//
+// The constants are untyped for use with the int results of Solar and
+// Lunar.  Kind gives them a name for printing and for encoding as text,
+// which includes JSON.
+type Kind int
+
+var kindNames = [...]string{"none", "partial", "annular", "annular-total",
+	"penumbral", "umbral", "total"}
+
+// String returns the name of the eclipse type, "annular-total" for example.
+func (k Kind) String() string {
+	if k < 0 || int(k) >= len(kindNames) {
+		return fmt.Sprintf("Kind(%d)", int(k))
+	}
+	return kindNames[k]
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding k as its name.
+func (k Kind) MarshalText() ([]byte, error) {
+	if k < 0 || int(k) >= len(kindNames) {
+		return nil, fmt.Errorf("Invalid eclipse kind %d.", int(k))
+	}
+	return []byte(kindNames[k]), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, decoding a name as
+// returned by String.
+func (k *Kind) UnmarshalText(text []byte) error {
+	for i, n := range kindNames {
+		if n == string(text) {
+			*k = Kind(i)
+			return nil
+		}
+	}
+	return fmt.Errorf("Invalid eclipse kind %q.", text)
+}
+
 // Snap returns k at specified quarter q nearest year y.
 // Cut and paste from moonphase.  Time corresponding to k needed in these
 // algorithms but otherwise not meaningful enough to export from moonphase.
@@ -93,6 +140,14 @@ func snap(y, q float64) float64 {
 	return math.Floor(k-q+.5) + q
 }
 
+// meanPhase returns the jde of the mean phase for k, (49.1) p. 349.
+// Also cut and paste from moonphase.
+func meanPhase(k float64) float64 {
+	const ck = 1 / 1236.85
+	return base.Horner(k*ck, 2451550.09766, 29.530588861/ck,
+		.00015437, -.00000015, .00000000073)
+}
+
 // Solar computes quantities related to solar eclipses.
 // 日食计算
 //
@@ -118,8 +173,13 @@ func snap(y, q float64) float64 {
 //
 // γ, u, and p are in units of equatorial Earth radii.
 func Solar(year float64) (eclipseType int, central bool, jmax, γ, u, p, mag float64) {
+	return solar(snap(year, 0))
+}
+
+// solar computes Solar for the New Moon of lunation k.
+func solar(k float64) (eclipseType int, central bool, jmax, γ, u, p, mag float64) {
 	var e bool
-	e, jmax, γ, u, _ = g(snap(year, 0), moonphase.MeanNew(year), -.4075, .1721)
+	e, jmax, γ, u, _ = g(k, meanPhase(k), -.4075, .1721)
 	p = u + .5461
 	if !e {
 		return // no eclipse
@@ -180,10 +240,14 @@ func Solar(year float64) (eclipseType int, central bool, jmax, γ, u, p, mag flo
 //
 // γ, σ, and ρ are in units of equatorial Earth radii.
 func Lunar(year float64) (eclipseType int, jmax, γ, ρ, σ, mag float64, sdTotal, sdPartial, sdPenumbral unit.Time) {
+	return lunar(snap(year, .5))
+}
+
+// lunar computes Lunar for the Full Moon of lunation k.
+func lunar(k float64) (eclipseType int, jmax, γ, ρ, σ, mag float64, sdTotal, sdPartial, sdPenumbral unit.Time) {
 	var e bool
 	var u, Mʹ float64
-	e, jmax, γ, u, Mʹ = g(snap(year, .5),
-		moonphase.MeanFull(year), -.4065, .1727)
+	e, jmax, γ, u, Mʹ = g(k, meanPhase(k), -.4065, .1727)
 	if !e {
 		return // no eclipse
 	}
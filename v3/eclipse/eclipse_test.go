@@ -4,12 +4,21 @@
 package eclipse_test
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
+	"strings"
+	"testing"
 
 	"github.com/mooncaker816/learnmeeus/v3/eclipse"
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/mooncaker816/learnmeeus/v3/moonphase"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/soniakeys/unit"
 )
 
-func ExampleSolar_1993() {
+func ExampleSolar_a() {
 	// Example 54.a, p. 384.
 	t, c, jm, γ, u, p, mag := eclipse.Solar(1993.38)
 	switch t {
@@ -50,7 +59,7 @@ func ExampleSolar_1993() {
 	// Penumbral radius:              +0.5558
 }
 
-func ExampleSolar_2009() {
+func ExampleSolar_b() {
 	// Example 54.b, p. 385.
 	t, c, jm, γ, u, p, mag := eclipse.Solar(2009.56)
 	switch t {
@@ -90,7 +99,7 @@ func ExampleSolar_2009() {
 	// Penumbral radius:              +0.5304
 }
 
-func ExampleLunar_1973() {
+func ExampleLunar_c() {
 	// Example 54.c, p. 385.
 	t, jm, γ, ρ, σ, mag, sdTotal, sdPartial, sdPenumbral :=
 		eclipse.Lunar(1973.46)
@@ -136,7 +145,7 @@ func ExampleLunar_1973() {
 	// Penumbral semiduration:        101 min
 }
 
-func ExampleLunar_1997() {
+func ExampleLunar_d() {
 	// Example 54.d, p. 386.
 	t, jm, γ, ρ, σ, mag, sdTotal, sdPartial, sdPenumbral :=
 		eclipse.Lunar(1997.7)
@@ -184,3 +193,294 @@ func ExampleLunar_1997() {
 	// Partial phase semiduration:     98 min
 	// Penumbral semiduration:        153 min
 }
+
+func besselian2017(t *testing.T) *eclipse.Besselian {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := eclipse.SolarBesselian(2017.64, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSolarBesselian(t *testing.T) {
+	// Total eclipse of 2017 August 21.  Elements published by NASA for
+	// T0 = 18ʰ TT.
+	b := besselian2017(t)
+	if b.T0 != 2457987.25 {
+		t.Fatal("T0:", b.T0)
+	}
+	x, y, d, l1, l2, _ := b.At(0)
+	if math.Abs(x+.129571) > .005 || math.Abs(y-.485416) > .005 ||
+		math.Abs(d.Deg()-11.86696) > .005 ||
+		math.Abs(l1-.542093) > .001 || math.Abs(l2+.004025) > .001 {
+		t.Error(x, y, d.Deg(), l1, l2)
+	}
+	if math.Abs(b.X[1]-.5406426) > 1e-4 || math.Abs(b.Y[1]+.14164) > 1e-4 ||
+		math.Abs(b.Mu[1].Deg()-15.00393) > 1e-3 {
+		t.Error(b.X[1], b.Y[1], b.Mu[1].Deg())
+	}
+	if math.Abs(b.F1.Tan()-.0046222) > 1e-6 ||
+		math.Abs(b.F2.Tan()-.0045992) > 1e-6 {
+		t.Error(b.F1.Tan(), b.F2.Tan())
+	}
+	if _, err := eclipse.SolarBesselian(2017.5, nil); err != eclipse.ErrorNoEclipse {
+		t.Error(err)
+	}
+}
+
+func TestSolarLocal(t *testing.T) {
+	b := besselian2017(t)
+	// Carbondale, Illinois, near the point of greatest duration.
+	carbondale := globe.Coord{
+		Lat: unit.AngleFromDeg(37.72),
+		Lon: unit.AngleFromDeg(89.22),
+	}
+	l, err := b.Local(carbondale, 120)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Type != eclipse.Total || l.Mag < 1 || l.Obscuration != 1 {
+		t.Fatal(l.Type, l.Mag, l.Obscuration)
+	}
+	if !(l.C1 < l.C2 && l.C2 < l.Max && l.Max < l.C3 && l.C3 < l.C4) {
+		t.Fatal("contacts out of order")
+	}
+	// totality of about 2ᵐ40ˢ
+	if d := (l.C3 - l.C2) * 86400; math.Abs(d-160) > 10 {
+		t.Error("duration:", d)
+	}
+	if l.Alt.Deg() < 60 || l.Alt.Deg() > 66 {
+		t.Error("altitude:", l.Alt.Deg())
+	}
+	// A partial eclipse farther north.
+	l, err = b.Local(globe.Coord{
+		Lat: unit.AngleFromDeg(45),
+		Lon: unit.AngleFromDeg(89.22),
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Type != eclipse.Partial || l.C2 != 0 || l.Mag >= 1 ||
+		l.Obscuration >= l.Mag {
+		t.Error(l.Type, l.C2, l.Mag, l.Obscuration)
+	}
+	// the type encodes as text, as in SolarEclipse
+	if js, err := json.Marshal(l); err != nil ||
+		!strings.Contains(string(js), `"type":"partial"`) {
+		t.Error(string(js), err)
+	}
+	// The penumbra does not reach the southern hemisphere.
+	_, err = b.Local(globe.Coord{Lat: unit.AngleFromDeg(-45)}, 0)
+	if err != eclipse.ErrorNoLocalEclipse {
+		t.Error(err)
+	}
+}
+
+func TestLunarContactTimes(t *testing.T) {
+	// Example 54.d, p. 386.
+	typ, c := eclipse.LunarContactTimes(1997.7)
+	if typ != eclipse.Total {
+		t.Fatal(typ)
+	}
+	for _, d := range []struct {
+		t, want float64
+	}{
+		{c.Max - c.P1, 153},
+		{c.U1 - c.P1, 153 - 98},
+		{c.U2 - c.U1, 98 - 30},
+		{c.U3 - c.U2, 2 * 30},
+		{c.P4 - c.Max, 153},
+	} {
+		if math.Abs(d.t*1440-d.want) > 1 {
+			t.Error(d.t*1440, d.want)
+		}
+	}
+}
+
+func TestGreatest(t *testing.T) {
+	// NASA gives greatest eclipse of 2017 August 21 at 36°58′N 87°40′W,
+	// duration 2ᵐ40ˢ, path width 115 km.
+	b := besselian2017(t)
+	g, err := b.Greatest(globe.Earth76)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if math.Abs(g.Coord.Lat.Deg()-36.97) > .1 ||
+		math.Abs(g.Coord.Lon.Deg()-87.67) > .2 {
+		t.Error("point:", g.Coord.Lat.Deg(), g.Coord.Lon.Deg())
+	}
+	if math.Abs(g.Duration.Sec()-160) > 3 || math.Abs(g.Width-115) > 2 {
+		t.Error("duration, width:", g.Duration.Sec(), g.Width)
+	}
+	if math.Abs(g.Alt.Deg()-64) > 1 {
+		t.Error("altitude:", g.Alt.Deg())
+	}
+}
+
+func TestPath(t *testing.T) {
+	b := besselian2017(t)
+	p, err := b.Path(globe.Earth76, unit.TimeFromMin(20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p) < 10 {
+		t.Fatal(len(p))
+	}
+	// ends of the central line are at sunrise and sunset
+	if p[0].Alt.Deg() > 1 || p[len(p)-1].Alt.Deg() > 1 {
+		t.Error("ends:", p[0].Alt.Deg(), p[len(p)-1].Alt.Deg())
+	}
+	for i, q := range p {
+		if i > 0 && q.JDE <= p[i-1].JDE {
+			t.Error("order:", i)
+		}
+		if !q.Limits || q.Alt.Deg() < 20 {
+			continue
+		}
+		if !(q.North.Lat > q.Coord.Lat && q.Coord.Lat > q.South.Lat) {
+			t.Error("limits:", q.North.Lat.Deg(), q.Coord.Lat.Deg(),
+				q.South.Lat.Deg())
+		}
+		if q.Width < 100 || q.Width > 125 {
+			t.Error("width:", q.Width)
+		}
+	}
+	// the 2017 eclipse is not central at its extremes
+	if _, err := b.PathAt(globe.Earth76, b.T0-3./24); err != eclipse.ErrorNotCentral {
+		t.Error(err)
+	}
+	for _, step := range []unit.Time{0, -60, unit.Time(math.NaN())} {
+		if _, err := b.Path(globe.Earth76, step); err != eclipse.ErrorStep {
+			t.Error("step", step, err)
+		}
+	}
+}
+
+func TestSolarEclipses(t *testing.T) {
+	r := eclipse.SolarEclipses(julian.CalendarGregorianToJD(2017, 1, 1),
+		julian.CalendarGregorianToJD(2025, 1, 1))
+	if len(r) != 18 {
+		t.Fatal(len(r))
+	}
+	for i, e := range r {
+		if i > 0 && e.JMax <= r[i-1].JMax {
+			t.Error("order:", i)
+		}
+		if int(e.K) != 358*e.Saros+223*e.Inex-62842 {
+			t.Error("inex:", i, e.Saros, e.Inex)
+		}
+	}
+	for _, d := range []struct {
+		i      int
+		typ    eclipse.Kind
+		saros  int
+		member int
+	}{
+		{0, eclipse.Annular, 140, 29},       // 2017 Feb 26
+		{1, eclipse.Total, 145, 22},         // 2017 Aug 21
+		{6, eclipse.Total, 127, 58},         // 2019 Jul 2
+		{14, eclipse.AnnularTotal, 129, 52}, // 2023 Apr 20
+		{16, eclipse.Total, 139, 30},        // 2024 Apr 8
+	} {
+		e := r[d.i]
+		if e.Type != d.typ || e.Saros != d.saros || e.SarosMember != d.member {
+			t.Error(d.i, e.Type, e.Saros, e.SarosMember)
+		}
+	}
+	// agrees with Solar
+	e := r[1]
+	typ, central, jmax, γ, _, _, _ := eclipse.Solar(2017.64)
+	if eclipse.Kind(typ) != e.Type || central != e.Central || jmax != e.JMax || γ != e.Gamma {
+		t.Error(e)
+	}
+	if e.Inex != 50 {
+		t.Error(e.Inex)
+	}
+}
+
+func TestLunarEclipses(t *testing.T) {
+	r := eclipse.LunarEclipses(julian.CalendarGregorianToJD(2021, 1, 1),
+		julian.CalendarGregorianToJD(2024, 1, 1))
+	want := []struct {
+		typ   eclipse.Kind
+		saros int
+	}{
+		{eclipse.Total, 121},     // 2021 May 26
+		{eclipse.Umbral, 126},    // 2021 Nov 19
+		{eclipse.Total, 131},     // 2022 May 16
+		{eclipse.Total, 136},     // 2022 Nov 8
+		{eclipse.Penumbral, 141}, // 2023 May 5
+		{eclipse.Umbral, 146},    // 2023 Oct 28
+	}
+	if len(r) != len(want) {
+		t.Fatal(len(r))
+	}
+	for i, w := range want {
+		if r[i].Type != w.typ || r[i].Saros != w.saros {
+			t.Error(i, r[i].Type, r[i].Saros)
+		}
+	}
+	if r[3].SarosMember != 20 {
+		t.Error(r[3].SarosMember)
+	}
+}
+
+func TestEclipseNearNone(t *testing.T) {
+	// with no eclipse, JMax is still the time of the phase
+	var n int
+	for y := 2000.; y < 2002; y += .05 {
+		if e := eclipse.SolarEclipseNear(y); e.Type == eclipse.None {
+			n++
+			if Δ := e.JMax - moonphase.New(y); math.Abs(Δ) > .05 {
+				t.Error("solar", y, Δ)
+			}
+		}
+		if e := eclipse.LunarEclipseNear(y); e.Type == eclipse.None {
+			n++
+			if Δ := e.JMax - moonphase.Full(y); math.Abs(Δ) > .05 {
+				t.Error("lunar", y, Δ)
+			}
+		}
+	}
+	if n == 0 {
+		t.Error("no case without eclipse")
+	}
+}
+
+func ExampleKind_String() {
+	e := eclipse.SolarEclipseNear(2023.3)
+	fmt.Println(e.Type)
+	// Output:
+	// annular-total
+}
+
+func TestKindJSON(t *testing.T) {
+	e := eclipse.LunarEclipseNear(2022.85)
+	b, err := json.Marshal(e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r eclipse.LunarEclipse
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatal(err)
+	}
+	if r != e || r.Type != eclipse.Total || r.Saros != 136 {
+		t.Fatal(string(b))
+	}
+	var m map[string]interface{}
+	json.Unmarshal(b, &m)
+	if m["type"] != "total" {
+		t.Error(m["type"])
+	}
+	var k eclipse.Kind
+	if err := json.Unmarshal([]byte(`"eclipse"`), &k); err == nil {
+		t.Error("no error for invalid name")
+	}
+	if _, err := json.Marshal(eclipse.Kind(-1)); err == nil {
+		t.Error("no error for invalid kind")
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package eclipse
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	"github.com/mooncaker816/learnmeeus/v3/iterate"
+	"github.com/soniakeys/unit"
+)
+
+// ErrorNotCentral is returned when the shadow axis does not meet the Earth
+// at the time of interest.
+var ErrorNotCentral = errors.New("Eclipse not central")
+
+// ErrorStep is returned by Path for a step that is not positive.
+var ErrorStep = errors.New("Step not positive")
+
+// PathPoint describes the path of a central solar eclipse at an instant.
+type PathPoint struct {
+	JDE   float64     // time of the point
+	Coord globe.Coord // point on the central line
+
+	// North and South are the northern and southern limits of the path
+	// of totality or annularity.  They are valid only if Limits is true.
+	// Near the ends of the path, where the shadow meets the Earth at a
+	// grazing angle, limits may not be found.
+	Limits       bool
+	North, South globe.Coord
+
+	Width    float64    // width of the path in units of the ellipsoid, typically km
+	Duration unit.Time  // duration of totality or annularity on the central line
+	Alt      unit.Angle // altitude of the Sun on the central line
+}
+
+// fundamental holds Besselian elements and their rates at an instant.
+type fundamental struct {
+	x, y, dx, dy float64
+	d, dd, μ, dμ unit.Angle
+	l2           float64
+	sd, cd       float64
+}
+
+func (b *Besselian) fundamental(t float64) (f fundamental) {
+	f.x, f.dx = poly(b.X, t)
+	f.y, f.dy = poly(b.Y, t)
+	f.d, f.dd = polyAngle(b.D, t)
+	f.μ, f.dμ = polyAngle(b.Mu, t)
+	f.l2, _ = poly(b.L2, t)
+	f.sd, f.cd = f.d.Sincos()
+	return
+}
+
+// surface finds the point of ellipsoid e with fundamental plane coordinates
+// ξ, η on the side of the Earth facing the Sun.
+//
+// Results are ζ and geographic coordinates of the point, with ok false if
+// no point of the Earth has coordinates ξ, η.
+func (f *fundamental) surface(e globe.Ellipsoid, ξ, η float64) (ζ float64, g globe.Coord, ok bool) {
+	// With the equatorial radius as unit, the ellipsoid is
+	// X² + Z² + Y²/b² = 1, with Y toward the pole.  In the fundamental
+	// plane, X = ξ, Y = η cos d + ζ sin d, Z = ζ cos d - η sin d.
+	b2 := (1 - e.Fl) * (1 - e.Fl)
+	A := f.cd*f.cd + f.sd*f.sd/b2
+	B := 2 * η * f.sd * f.cd * (1/b2 - 1)
+	C := ξ*ξ + η*η*(f.sd*f.sd+f.cd*f.cd/b2) - 1
+	disc := B*B - 4*A*C
+	if disc < 0 {
+		return
+	}
+	ζ = (-B + math.Sqrt(disc)) / (2 * A)
+	if ζ < 0 {
+		return
+	}
+	X := ξ
+	Y := η*f.cd + ζ*f.sd
+	Z := ζ*f.cd - η*f.sd
+	// geographic latitude from the normal to the ellipsoid
+	g.Lat = unit.Angle(math.Atan2(Y, b2*math.Hypot(X, Z)))
+	// longitude is measured positively westward
+	g.Lon = unit.Angle(math.Remainder((f.μ - unit.Angle(math.Atan2(X, Z))).Rad(),
+		2*math.Pi))
+	return ζ, g, true
+}
+
+// relative returns the velocity of the shadow axis relative to a point of
+// the Earth with fundamental plane coordinates ξ, η, ζ, in Earth radii per
+// hour.
+func (f *fundamental) relative(ξ, η, ζ float64) (a, b float64) {
+	// Z of surface is ρ cos φ′ cos H
+	Z := ζ*f.cd - η*f.sd
+	dξ := f.dμ.Rad() * Z
+	dη := f.dμ.Rad()*ξ*f.sd - ζ*f.dd.Rad()
+	return f.dx - dξ, f.dy - dη
+}
+
+// PathAt computes the central line and limits of a central solar eclipse
+// at an instant.
+// 计算某一时刻日食中心线和全食（环食）带界限
+//
+// Argument e gives the figure of the Earth.  Its flattening is used for
+// the shape of the Earth and its radius Er to scale the width of the path.
+// Argument jde is the time of interest.
+//
+// ErrorNotCentral is returned if the shadow axis misses the Earth at jde.
+func (b *Besselian) PathAt(e globe.Ellipsoid, jde float64) (*PathPoint, error) {
+	t := (jde - b.T0) * 24
+	f := b.fundamental(t)
+	ζ, g, ok := f.surface(e, f.x, f.y)
+	if !ok {
+		return nil, ErrorNotCentral
+	}
+	p := &PathPoint{JDE: jde, Coord: g}
+	p.Alt = unit.Angle(math.Asin(math.Min(ζ, 1)))
+	L2 := math.Abs(f.l2 - ζ*b.F2.Tan())
+	a, bb := f.relative(f.x, f.y, ζ)
+	p.Duration = unit.TimeFromHour(2 * L2 / math.Hypot(a, bb))
+	// limits
+	n, nok := b.limit(e, &f, 1)
+	s, sok := b.limit(e, &f, -1)
+	if nok && sok {
+		if n.Lat < s.Lat {
+			n, s = s, n
+		}
+		p.Limits = true
+		p.North, p.South = n, s
+		p.Width = e.Distance(n, s)
+	}
+	return p, nil
+}
+
+// limit finds a point on the edge of the umbra or antumbra where the
+// edge is tangent to the path.
+//
+// Side s is 1 or -1 to select one limit or the other.
+func (b *Besselian) limit(e globe.Ellipsoid, f *fundamental, s float64) (g globe.Coord, ok bool) {
+	ξ, η := f.x, f.y
+	var ζ float64
+	for i := 0; i < 20; i++ {
+		if ζ, g, ok = f.surface(e, ξ, η); !ok {
+			return
+		}
+		a, bb := f.relative(ξ, η, ζ)
+		n := math.Hypot(a, bb)
+		L2 := math.Abs(f.l2 - ζ*b.F2.Tan())
+		// offset from the axis perpendicular to the relative motion
+		ξn := f.x - s*L2*bb/n
+		ηn := f.y + s*L2*a/n
+		dξ, dη := ξn-ξ, ηn-η
+		ξ, η = ξn, ηn
+		if math.Abs(dξ) < 1e-9 && math.Abs(dη) < 1e-9 {
+			break
+		}
+	}
+	_, g, ok = f.surface(e, ξ, η)
+	return
+}
+
+// Path computes the central line of a central solar eclipse.
+// 计算日食的中心线
+//
+// Points are computed at times that are multiples of step after T0 while
+// the shadow axis meets the Earth.  The first and last points are at the
+// beginning and end of the central line, where the Sun is on the horizon.
+//
+// ErrorNotCentral is returned if the shadow axis does not meet the Earth
+// during the eclipse.  ErrorStep is returned if step is not positive.
+func (b *Besselian) Path(e globe.Ellipsoid, step unit.Time) ([]PathPoint, error) {
+	if !(step > 0) {
+		return nil, ErrorStep
+	}
+	hit := func(t float64) bool {
+		f := b.fundamental(t)
+		_, _, ok := f.surface(e, f.x, f.y)
+		return ok
+	}
+	g, err := b.Greatest(e)
+	if err != nil {
+		return nil, err
+	}
+	tg := (g.JDE - b.T0) * 24
+	if !hit(tg) {
+		return nil, ErrorNotCentral
+	}
+	edge := func(t1, t2 float64) float64 {
+		return iterate.BinaryRoot(func(t float64) float64 {
+			if hit(t) {
+				return -1
+			}
+			return 1
+		}, t1, t2)
+	}
+	// extent of the central line.  the axis meets the Earth at tg and
+	// takes less than 3.5 hours to cross it either way.
+	t1, t2 := tg-3.5, tg+3.5
+	tBegin := edge(tg, t1)
+	tEnd := edge(tg, t2)
+	var path []PathPoint
+	add := func(t float64) {
+		if p, err := b.PathAt(e, b.T0+t/24); err == nil {
+			path = append(path, *p)
+		}
+	}
+	// nudge endpoints inside the central line by more than the resolution
+	// of a julian day, about 1e-8 hour.
+	add(tBegin + 1e-6)
+	h := step.Hour()
+	for t := math.Floor(tBegin/h+1) * h; t < tEnd; t += h {
+		add(t)
+	}
+	add(tEnd - 1e-6)
+	return path, nil
+}
+
+// Greatest returns the point and time of greatest eclipse, when the shadow
+// axis passes closest to the center of the Earth.
+// 计算食甚的时间和地点
+//
+// For a central eclipse the result is the point of the central line at
+// that time.  For a non-central eclipse the result is the point on the
+// limb of the Earth nearest the shadow axis, and only JDE, Coord, and Alt
+// are set.
+func (b *Besselian) Greatest(e globe.Ellipsoid) (*PathPoint, error) {
+	t := 0.
+	for i := 0; i < 50; i++ {
+		x, dx := poly(b.X, t)
+		y, dy := poly(b.Y, t)
+		τ := -(x*dx + y*dy) / (dx*dx + dy*dy)
+		t += τ
+		if math.Abs(τ) < 1e-9 {
+			break
+		}
+	}
+	jde := b.T0 + t/24
+	if p, err := b.PathAt(e, jde); err == nil {
+		return p, nil
+	}
+	// point of the limb, ζ = 0, in the direction of the axis
+	f := b.fundamental(t)
+	b2 := (1 - e.Fl) * (1 - e.Fl)
+	k := 1 / math.Sqrt(f.x*f.x+f.y*f.y*(f.sd*f.sd+f.cd*f.cd/b2))
+	_, g, ok := f.surface(e, f.x*k*(1-1e-12), f.y*k*(1-1e-12))
+	if !ok {
+		return nil, ErrorNotCentral
+	}
+	return &PathPoint{JDE: jde, Coord: g}, nil
+}
@@ -23,11 +23,11 @@ import (
 // Position returns observed equatorial coordinates of a planet at a given time.
 // 行星p的地心视赤经，视赤纬
 //
-// Argument p must be a valid V87Planet object for the observed planet.
-// Argument earth must be a valid V87Planet object for Earth.
+// Argument p must be a valid Planet object for the observed planet.
+// Argument earth must be a valid Planet object for Earth.
 //
 // Results are right ascension and declination, α and δ in radians.
-func Position(p, earth *pp.V87Planet, jde float64) (α unit.RA, δ unit.Angle) {
+func Position(p, earth pp.Planet, jde float64) (α unit.RA, δ unit.Angle) {
 	L0, B0, R0 := earth.Position(jde) // 地球日心坐标
 	L, B, R := p.Position(jde)        // 行星日心坐标
 	sB0, cB0 := B0.Sincos()
@@ -72,11 +72,11 @@ type Elements struct {
 
 // Position returns observed equatorial coordinates of a body with Keplerian elements.
 //
-// Argument e must be a valid V87Planet object for Earth.
+// Argument e must be a valid Planet object for Earth.
 //
 // Results are right ascension and declination α and δ, and elongation ψ,
 // all in radians.
-func (k *Elements) Position(jde float64, e *pp.V87Planet) (α unit.RA, δ, ψ unit.Angle) {
+func (k *Elements) Position(jde float64, e pp.Planet) (α unit.RA, δ, ψ unit.Angle) {
 	// (33.6) p. 227
 	n := base.K / k.Axis / math.Sqrt(k.Axis)
 	const sε = base.SOblJ2000
@@ -124,7 +124,7 @@ func (k *Elements) Position(jde float64, e *pp.V87Planet) (α unit.RA, δ, ψ un
 // coodinates of a body.
 //
 // Results are J2000 right ascention, declination, and elongation.
-func AstrometricJ2000(f func(float64) (x, y, z float64), jde float64, e *pp.V87Planet) (α unit.RA, δ, ψ unit.Angle) {
+func AstrometricJ2000(f func(float64) (x, y, z float64), jde float64, e pp.Planet) (α unit.RA, δ, ψ unit.Angle) {
 	X, Y, Z := solarxyz.PositionJ2000(e, jde) // 太阳直角坐标
 	x, y, z := f(jde)                         // 日心直角赤道坐标
 	// (33.10) p. 229
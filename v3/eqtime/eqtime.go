@@ -22,7 +22,7 @@ import (
 // with planetposition.LoadPlanet.
 //
 // Result is equation of time as an hour angle.
-func E(jde float64, e *pp.V87Planet) unit.HourAngle {
+func E(jde float64, e pp.Planet) unit.HourAngle {
 	τ := base.J2000Century(jde) * .1 // J2000儒略日千年数
 	L0 := l0(τ)
 	// code duplicated from solar.ApparentEquatorialVSOP87 so that
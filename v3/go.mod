@@ -1,5 +1,7 @@
 module github.com/mooncaker816/learnmeeus/v3
 
+go 1.16
+
 require (
 	github.com/soniakeys/sexagesimal v1.0.0
 	github.com/soniakeys/unit v1.0.0
@@ -0,0 +1,260 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package jm
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+)
+
+// A JMonth specifies a month of the Jewish Calendar.
+//
+// Months are numbered from Nisan, as in the Bible, although the year
+// number changes at Tishri.  In a leap year, Adar is Adar I and is
+// followed by AdarII.
+type JMonth int
+
+// Months of the Jewish Calendar.
+const (
+	Nisan JMonth = 1 + iota
+	Iyyar
+	Sivan
+	Tammuz
+	Av
+	Elul
+	Tishri
+	Heshvan
+	Kislev
+	Tevet
+	Shevat
+	Adar
+	AdarII
+)
+
+var jmonths = [13]string{
+	"Nisan",
+	"Iyyar",
+	"Sivan",
+	"Tammuz",
+	"Av",
+	"Elul",
+	"Tishri",
+	"Ḥeshvan",
+	"Kislev",
+	"Ṭevet",
+	"Shevaṭ",
+	"Adar",
+	"Adar II",
+}
+
+// String returns the Romanization of the month ("Nisan", "Iyyar", ...).
+//
+// Adar is "Adar" regardless of the year.  See Name.
+func (m JMonth) String() string {
+	if m < Nisan || m > AdarII {
+		return fmt.Sprintf("JMonth(%d)", int(m))
+	}
+	return jmonths[m-1]
+}
+
+// Name returns the Romanization of the month in a year, "Adar I" for Adar
+// of a leap year.
+func (m JMonth) Name(y int) string {
+	if m == Adar && JewishLeapYear(y) {
+		return "Adar I"
+	}
+	return m.String()
+}
+
+// jewishEpoch is the julian day of 1 Tishri of year 1, 3761 B.C. October 7
+// in the Julian calendar.
+const jewishEpoch = 347997.5
+
+// JewishLeapYear returns true if year y of the Jewish Calendar is a leap
+// year of 13 months.
+func JewishLeapYear(y int) bool {
+	r := (7*y + 1) % 19
+	if r < 0 {
+		r += 19
+	}
+	return r < 7
+}
+
+// jewishDelay returns days from the epoch to the molad of Tishri of year y,
+// delayed by the rule that Rosh Hashanah not fall on Sunday, Wednesday, or
+// Friday.
+func jewishDelay(y int) int {
+	months := base.FloorDiv(235*y-234, 19)
+	parts := 12084 + 13753*int64(months)
+	day := 29*months + int(base.FloorDiv64(parts, 25920))
+	if (3*(day+1))%7 < 3 {
+		day++
+	}
+	return day
+}
+
+// jewishNewYear returns the julian day of 1 Tishri of year y.
+func jewishNewYear(y int) float64 {
+	ny0 := jewishDelay(y - 1)
+	ny1 := jewishDelay(y)
+	ny2 := jewishDelay(y + 1)
+	// delays keeping year lengths within the allowed values
+	switch {
+	case ny2-ny1 == 356:
+		ny1 += 2
+	case ny1-ny0 == 382:
+		ny1++
+	}
+	return jewishEpoch + float64(ny1)
+}
+
+// JewishYearDays returns the number of days in year y of the Jewish
+// Calendar, one of 353, 354, 355, 383, 384, or 385.
+func JewishYearDays(y int) int {
+	return int(jewishNewYear(y+1) - jewishNewYear(y))
+}
+
+// JewishMonthDays returns the number of days in month m of year y.
+//
+// Heshvan and Kislev vary with the length of the year.  In a "deficient"
+// year both have 29 days, in a "regular" year Heshvan has 29 and Kislev
+// 30, and in a "complete" year both have 30.
+func JewishMonthDays(y int, m JMonth) int {
+	switch m {
+	case Iyyar, Tammuz, Elul, Tevet, AdarII:
+		return 29
+	case Adar:
+		if !JewishLeapYear(y) {
+			return 29
+		}
+	case Heshvan:
+		if JewishYearDays(y)%10 != 5 {
+			return 29
+		}
+	case Kislev:
+		if JewishYearDays(y)%10 == 3 {
+			return 29
+		}
+	}
+	return 30
+}
+
+// jewishLastMonth returns the last month of year y.
+func jewishLastMonth(y int) JMonth {
+	if JewishLeapYear(y) {
+		return AdarII
+	}
+	return Adar
+}
+
+// JewishToJD returns the julian day of 0h UT of the civil day corresponding
+// to a date of the Jewish Calendar.
+//
+// The Jewish day begins at the sunset before.  Results are not checked for
+// validity of the date.
+func JewishToJD(y int, m JMonth, d int) float64 {
+	n := d - 1
+	if m < Tishri {
+		for i := Tishri; i <= jewishLastMonth(y); i++ {
+			n += JewishMonthDays(y, i)
+		}
+		for i := Nisan; i < m; i++ {
+			n += JewishMonthDays(y, i)
+		}
+	} else {
+		for i := Tishri; i < m; i++ {
+			n += JewishMonthDays(y, i)
+		}
+	}
+	return jewishNewYear(y) + float64(n)
+}
+
+// JDToJewish returns the date of the Jewish Calendar of the civil day
+// containing jd.
+func JDToJewish(jd float64) (y int, m JMonth, d int) {
+	jd = math.Floor(jd-.5) + .5
+	// mean length of year is 35975351/98496 days
+	y = int(math.Floor((jd-jewishEpoch)*98496/35975351)) + 1
+	for jewishNewYear(y) > jd {
+		y--
+	}
+	for jewishNewYear(y+1) <= jd {
+		y++
+	}
+	m = Tishri
+	if jd >= JewishToJD(y, Nisan, 1) {
+		m = Nisan
+	}
+	for jd >= JewishToJD(y, m, 1)+float64(JewishMonthDays(y, m)) {
+		m++
+	}
+	return y, m, int(jd-JewishToJD(y, m, 1)) + 1
+}
+
+// JewishToGregorian converts a date of the Jewish Calendar to a Gregorian
+// calendar date.
+//
+// The Gregorian calendar is proleptic, used for dates before 1582 as well.
+func JewishToGregorian(y int, m JMonth, d int) (gy, gm, gd int) {
+	gy, mon, gd := julian.JDToTime(JewishToJD(y, m, d)).Date()
+	return gy, int(mon), gd
+}
+
+// GregorianToJewish converts a Gregorian calendar date to a date of the
+// Jewish Calendar.
+func GregorianToJewish(gy, gm, gd int) (y int, m JMonth, d int) {
+	return JDToJewish(julian.CalendarGregorianToJD(gy, gm, float64(gd)))
+}
+
+// JewishHoliday identifies a holiday of the Jewish Calendar.
+type JewishHoliday int
+
+// Holidays, by their first day.
+const (
+	RoshHashanah JewishHoliday = iota // 1 Tishri
+	YomKippur                         // 10 Tishri
+	Sukkot                            // 15 Tishri
+	Hanukkah                          // 25 Kislev
+	Purim                             // 14 Adar, or Adar II in a leap year
+	Pesach                            // 15 Nisan
+	Shavuot                           // 6 Sivan
+)
+
+var holidayNames = [...]string{"Rosh Hashanah", "Yom Kippur", "Sukkot",
+	"Hanukkah", "Purim", "Pesach", "Shavuot"}
+
+// String returns the name of the holiday.
+func (h JewishHoliday) String() string {
+	if h < 0 || int(h) >= len(holidayNames) {
+		return fmt.Sprintf("JewishHoliday(%d)", int(h))
+	}
+	return holidayNames[h]
+}
+
+// JD returns the julian day of 0h UT of the civil day of the holiday in
+// Gregorian year gy.
+//
+// The holiday begins at sunset of the day before.
+func (h JewishHoliday) JD(gy int) float64 {
+	// Jewish year of Tishri through Kislev of gy
+	y := gy + 3761
+	switch h {
+	case RoshHashanah:
+		return JewishToJD(y, Tishri, 1)
+	case YomKippur:
+		return JewishToJD(y, Tishri, 10)
+	case Sukkot:
+		return JewishToJD(y, Tishri, 15)
+	case Hanukkah:
+		return JewishToJD(y, Kislev, 25)
+	case Purim:
+		return JewishToJD(y-1, jewishLastMonth(y-1), 14)
+	case Pesach:
+		return JewishToJD(y-1, Nisan, 15)
+	}
+	return JewishToJD(y-1, Sivan, 6)
+}
@@ -17,6 +17,16 @@
 // that they are not direct inverses:  JulianToGregorian returns the day number
 // of the day of the Gregorian year, but GregorianToJulian wants the Gregorian
 // month and day of month as input.
+//
+// Beyond the chapter, the MoslemCalendar interface covers other variants
+// of the Moslem calendar:  Tabular for the four leap year schemes and two
+// epochs of the arithmetic calendar, MonthTable for published tables such
+// as the Umm al-Qura calendar, and Observed for months that begin with a
+// sighting of the new crescent.
+//
+// Also beyond the chapter, JewishToJD and JDToJewish convert dates of the
+// Jewish calendar by the arithmetic of the molad and its postponements,
+// giving month lengths and the dates of holidays of any year.
 package jm
 
 import (
@@ -5,9 +5,15 @@ package jm_test
 
 import (
 	"fmt"
+	"testing"
 	"time"
 
+	"github.com/mooncaker816/learnmeeus/v3/crescent"
+	"github.com/mooncaker816/learnmeeus/v3/globe"
 	"github.com/mooncaker816/learnmeeus/v3/jm"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/soniakeys/unit"
 )
 
 func ExampleJewishCalendar() {
@@ -68,3 +74,168 @@ func ExampleJulianToMoslem() {
 	// Output:
 	// 2 Ṣafar of A.H. 1412
 }
+
+func TestTabular(t *testing.T) {
+	// leap years of the schemes
+	for s, want := range [][]int{
+		{2, 5, 7, 10, 13, 15, 18, 21, 24, 26, 29},
+		{2, 5, 7, 10, 13, 16, 18, 21, 24, 26, 29},
+		{2, 5, 8, 10, 13, 16, 19, 21, 24, 27, 29},
+		{2, 5, 8, 11, 13, 16, 19, 21, 24, 27, 30},
+	} {
+		c := jm.Tabular{Leap: jm.LeapScheme(s), Epoch: jm.CivilEpoch}
+		var got []int
+		for y := 1441; y <= 1470; y++ {
+			if c.LeapYear(y) {
+				got = append(got, (y-1)%30+1)
+			}
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Error(s, got)
+		}
+	}
+	// Example 9.b, p. 75.
+	c := jm.Tabular{Leap: jm.LeapII, Epoch: jm.CivilEpoch}
+	jd, err := c.JD(1421, 1, 1)
+	if err != nil || jd != julian.CalendarGregorianToJD(2000, 4, 6) {
+		t.Fatal(jd, err)
+	}
+	a := jm.Tabular{Leap: jm.LeapII, Epoch: jm.AstronomicalEpoch}
+	if y, m, d, _ := a.Date(jd); y != 1421 || m != 1 || d != 2 {
+		t.Error(y, m, d)
+	}
+	if _, err := c.JD(1421, 2, 30); err != jm.ErrorInvalidDate {
+		t.Error(err)
+	}
+	// round trips, and agrees with MoslemToJulian.
+	for jd := 2200000.5; jd < 2460000.5; jd += 97 {
+		y, m, d, _ := c.Date(jd)
+		if j2, _ := c.JD(y, m, d); j2 != jd {
+			t.Fatal(jd, y, m, d, j2)
+		}
+		jy, dn := jm.MoslemToJulian(y, m, d)
+		if j2 := julian.CalendarJulianToJD(jy, 1, float64(dn)); j2 != jd {
+			t.Fatal(jd, y, m, d, j2)
+		}
+	}
+}
+
+func TestMonthTable(t *testing.T) {
+	// Umm al-Qura, 1444 Shaʿbān to Shawwāl.
+	c := jm.NewMonthTable(1444, 8, 2023, 2, 21, []int{30, 29, 30})
+	y, m, d, err := jm.GregorianToMoslem(c, 2023, 3, 23)
+	if err != nil || y != 1444 || m != 9 || d != 1 {
+		t.Error(y, m, d, err)
+	}
+	gy, gm, gd, err := jm.MoslemToGregorian(c, 1444, 10, 1)
+	if err != nil || gy != 2023 || gm != 4 || gd != 21 {
+		t.Error(gy, gm, gd, err)
+	}
+	if _, err := c.JD(1444, 9, 30); err != jm.ErrorInvalidDate {
+		t.Error(err)
+	}
+	if _, err := c.JD(1444, 11, 1); err != jm.ErrorOutOfTable {
+		t.Error(err)
+	}
+}
+
+func TestObserved(t *testing.T) {
+	// Ramaḍān 1444 began in Saudi Arabia on 2023 March 23.  New Moon was
+	// at 17ʰ23ᵐ UT March 21, after sunset at Mecca.
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mecca := globe.Coord{
+		Lat: unit.AngleFromDeg(21.42),
+		Lon: unit.AngleFromDeg(-39.83),
+	}
+	for _, c := range []jm.MoslemCalendar{
+		jm.Observed{Rule: jm.ConjunctionRule(mecca, e)},
+		jm.Observed{Rule: jm.CrescentRule(mecca, e, crescent.YallopB)},
+	} {
+		gy, gm, gd, err := jm.MoslemToGregorian(c, 1444, 9, 1)
+		if err != nil || gy != 2023 || gm != 3 || gd != 23 {
+			t.Error(gy, gm, gd, err)
+		}
+		y, m, d, err := jm.GregorianToMoslem(c, 2023, 4, 20)
+		if err != nil || y != 1444 || m != 9 || d != 29 {
+			t.Error(y, m, d, err)
+		}
+	}
+	// dates before AH 1 round trip
+	o := jm.Observed{Rule: func(y, m, d int) bool { return true }}
+	for y := -1; y <= 1; y++ {
+		for m := 1; m <= 12; m++ {
+			jd, err := o.JD(y, m, 1)
+			if err != nil {
+				t.Fatal(y, m, err)
+			}
+			if y1, m1, d1, err := o.Date(jd); err != nil || y1 != y || m1 != m || d1 != 1 {
+				t.Error(y, m, "got", y1, m1, d1, err)
+			}
+		}
+	}
+}
+
+func ExampleJewishToGregorian() {
+	// Pesach and New Year of Example 9.a, p. 73.
+	fmt.Println(jm.JewishToGregorian(5750, jm.Nisan, 15))
+	fmt.Println(jm.JewishToGregorian(5751, jm.Tishri, 1))
+	y, m, d := jm.GregorianToJewish(2024, 3, 24)
+	fmt.Println(d, m.Name(y), y)
+	// Output:
+	// 1990 4 10
+	// 1990 9 20
+	// 14 Adar II 5784
+}
+
+func ExampleJewishHoliday_JD() {
+	for _, h := range []jm.JewishHoliday{jm.Purim, jm.Pesach,
+		jm.YomKippur, jm.Sukkot, jm.Hanukkah} {
+		fmt.Printf("%-10s %s\n", h, julian.JDToTime(h.JD(2023)).Format("Jan 2"))
+	}
+	// Output:
+	// Purim      Mar 7
+	// Pesach     Apr 6
+	// Yom Kippur Sep 25
+	// Sukkot     Sep 30
+	// Hanukkah   Dec 8
+}
+
+func TestJewish(t *testing.T) {
+	for y := 1583; y < 2500; y++ {
+		A, mP, dP, mNY, dNY, months, days := jm.JewishCalendar(y)
+		if gy, gm, gd := jm.JewishToGregorian(A, jm.Nisan, 15); gy != y || gm != mP || gd != dP {
+			t.Fatal(y, "Pesach", gy, gm, gd)
+		}
+		if gy, gm, gd := jm.JewishToGregorian(A+1, jm.Tishri, 1); gy != y || gm != mNY || gd != dNY {
+			t.Fatal(y, "New Year", gy, gm, gd)
+		}
+		if jm.JewishLeapYear(A) != (months == 13) {
+			t.Fatal(y, "months", months)
+		}
+		if n := jm.JewishYearDays(A + 1); n != days {
+			t.Fatal(y, "days", n, days)
+		}
+	}
+	// round trip, and month lengths summing to year length
+	for y := 5700; y < 5800; y++ {
+		n := 0
+		for m := jm.Nisan; m <= jm.AdarII; m++ {
+			if m == jm.AdarII && !jm.JewishLeapYear(y) {
+				continue
+			}
+			md := jm.JewishMonthDays(y, m)
+			n += md
+			for d := 1; d <= md; d++ {
+				if yr, mr, dr := jm.JDToJewish(jm.JewishToJD(y, m, d)); yr != y || mr != m || dr != d {
+					t.Fatal(y, m, d, "round trip", yr, mr, dr)
+				}
+			}
+		}
+		if n != jm.JewishYearDays(y) {
+			t.Fatal(y, "month days", n)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package jm
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/crescent"
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/mooncaker816/learnmeeus/v3/moonphase"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/soniakeys/unit"
+)
+
+// Errors returned by MoslemCalendar implementations.
+var (
+	ErrorInvalidDate = errors.New("Invalid date")
+	ErrorOutOfTable  = errors.New("Date not covered by table")
+)
+
+// MoslemCalendar is a variant of the Moslem calendar.
+//
+// JD returns the julian day of 0h UT of the civil day corresponding to
+// a Moslem date.  The Moslem day begins at the sunset before.  Date is the
+// inverse, returning the Moslem date of the civil day containing jd.
+type MoslemCalendar interface {
+	JD(y, m, d int) (float64, error)
+	Date(jd float64) (y, m, d int, err error)
+}
+
+// MoslemToGregorian converts a date of any Moslem calendar variant to a
+// Gregorian calendar date.
+//
+// The Gregorian calendar is proleptic, used for dates before 1582 as well.
+func MoslemToGregorian(c MoslemCalendar, y, m, d int) (gy, gm, gd int, err error) {
+	jd, err := c.JD(y, m, d)
+	if err != nil {
+		return
+	}
+	t := julian.JDToTime(jd)
+	gy, mon, gd := t.Date()
+	return gy, int(mon), gd, nil
+}
+
+// GregorianToMoslem converts a Gregorian calendar date to a date of any
+// Moslem calendar variant.
+func GregorianToMoslem(c MoslemCalendar, gy, gm, gd int) (y, m, d int, err error) {
+	return c.Date(julian.CalendarGregorianToJD(gy, gm, float64(gd)))
+}
+
+// LeapScheme identifies the leap years of a 30 year cycle of the tabular
+// Moslem calendar.
+type LeapScheme int
+
+// Leap year schemes.  Comments give the leap years of the cycle.
+const (
+	LeapI   LeapScheme = iota // 2, 5, 7, 10, 13, 15, 18, 21, 24, 26, 29
+	LeapII                    // 2, 5, 7, 10, 13, 16, 18, 21, 24, 26, 29, as Meeus
+	LeapIII                   // 2, 5, 8, 10, 13, 16, 19, 21, 24, 27, 29
+	LeapIV                    // 2, 5, 8, 11, 13, 16, 19, 21, 24, 27, 30
+)
+
+// year y of the cycle is leap if (11y + leapC) mod 30 < 11.
+var leapC = [...]int{15, 14, 11, 9}
+
+// Epochs of the tabular Moslem calendar, the julian days of 1 Muḥarram
+// of year 1.
+const (
+	CivilEpoch        = 1948439.5 // Friday, 622 July 16, Julian calendar
+	AstronomicalEpoch = 1948438.5 // Thursday, 622 July 15
+)
+
+// Tabular is an arithmetic Moslem calendar of alternating 30 and 29 day
+// months and 30 year cycles of leap years.
+//
+// The zero value is not valid.  The calendar of MoslemToJulian and
+// JulianToMoslem is Tabular{LeapII, CivilEpoch}.
+type Tabular struct {
+	Leap  LeapScheme
+	Epoch float64 // CivilEpoch or AstronomicalEpoch
+}
+
+// LeapYear returns true if year y is a leap year of 355 days.
+func (t Tabular) LeapYear(y int) bool {
+	r := (11*y + leapC[t.Leap]) % 30
+	return r >= 0 && r < 11 || r < 0 && r+30 < 11
+}
+
+// start returns the jd of 1 Muḥarram of year y.
+func (t Tabular) start(y int) float64 {
+	return t.Epoch + float64(354*(y-1)+base.FloorDiv(11*y+leapC[t.Leap]-11, 30))
+}
+
+// monthDays returns the number of days before month m of a year.
+func monthDays(m int) int {
+	return 29*(m-1) + m/2
+}
+
+// JD returns the julian day of 0h UT of the civil day corresponding to a
+// Moslem date.
+func (t Tabular) JD(y, m, d int) (float64, error) {
+	n := 30 - (m+1)%2
+	if m == 12 && t.LeapYear(y) {
+		n = 30
+	}
+	if m < 1 || m > 12 || d < 1 || d > n {
+		return 0, ErrorInvalidDate
+	}
+	return t.start(y) + float64(monthDays(m)+d-1), nil
+}
+
+// Date returns the Moslem date of the civil day containing jd.
+func (t Tabular) Date(jd float64) (y, m, d int, err error) {
+	jd = math.Floor(jd-.5) + .5
+	y = int(math.Floor((jd-t.Epoch)/(10631./30))) + 1
+	for t.start(y) > jd {
+		y--
+	}
+	for t.start(y+1) <= jd {
+		y++
+	}
+	n := int(jd - t.start(y))
+	for m = 12; monthDays(m) > n; m-- {
+	}
+	return y, m, n - monthDays(m) + 1, nil
+}
+
+// MonthTable is a Moslem calendar given by a table of month starts, as
+// published for the Umm al-Qura calendar of Saudi Arabia.
+type MonthTable struct {
+	Year, Month int // Moslem year and month of Starts[0]
+
+	// Starts holds julian days of 0h UT of the first day of consecutive
+	// months.  The last entry marks the end of the table.
+	Starts []float64
+}
+
+// NewMonthTable builds a MonthTable from the lengths of consecutive
+// months.
+//
+//	y, m are the Moslem year and month of the first month.
+//	gy, gm, gd are the Gregorian date of its first day.
+//	lengths are the numbers of days of the months, 29 or 30.
+func NewMonthTable(y, m, gy, gm, gd int, lengths []int) *MonthTable {
+	t := &MonthTable{Year: y, Month: m}
+	jd := julian.CalendarGregorianToJD(gy, gm, float64(gd))
+	t.Starts = append(t.Starts, jd)
+	for _, n := range lengths {
+		jd += float64(n)
+		t.Starts = append(t.Starts, jd)
+	}
+	return t
+}
+
+// JD returns the julian day of 0h UT of the civil day corresponding to a
+// Moslem date.
+func (t *MonthTable) JD(y, m, d int) (float64, error) {
+	if m < 1 || m > 12 || d < 1 || d > 30 {
+		return 0, ErrorInvalidDate
+	}
+	i := (y-t.Year)*12 + m - t.Month
+	if i < 0 || i >= len(t.Starts)-1 {
+		return 0, ErrorOutOfTable
+	}
+	jd := t.Starts[i] + float64(d-1)
+	if jd >= t.Starts[i+1] {
+		return 0, ErrorInvalidDate
+	}
+	return jd, nil
+}
+
+// Date returns the Moslem date of the civil day containing jd.
+func (t *MonthTable) Date(jd float64) (y, m, d int, err error) {
+	jd = math.Floor(jd-.5) + .5
+	if len(t.Starts) < 2 || jd < t.Starts[0] || jd >= t.Starts[len(t.Starts)-1] {
+		return 0, 0, 0, ErrorOutOfTable
+	}
+	i := 0
+	for t.Starts[i+1] <= jd {
+		i++
+	}
+	n := t.Month - 1 + i
+	return t.Year + base.FloorDiv(n, 12), n%12 + 1, int(jd-t.Starts[i]) + 1, nil
+}
+
+// VisibilityRule reports whether the new crescent is seen on the evening
+// of a Gregorian date.
+type VisibilityRule func(yr, mon, day int) bool
+
+// Observed is a Moslem calendar with months that begin the day after the
+// new crescent is seen, as determined by Rule.
+//
+// Months are numbered by lunation, so that a month of Observed begins one
+// to three days after the New Moon preceding the same month of the tabular
+// calendar.  Rule is tried on the evening of the date of New Moon, in UT,
+// and the two following evenings.  If it reports no sighting, the month
+// begins the day after the third evening.
+type Observed struct {
+	Rule VisibilityRule
+}
+
+// lunation returns k of (49.2) for the New Moon preceding month m of year
+// y.  For 1 Muḥarram 1421, 2000 April 6, it is 3, the New Moon of April 4.
+func lunation(y, m int) int {
+	return 12*(y-1) + m - 1 - 17037
+}
+
+// monthStart returns the jd of 0h UT of the first day of the month
+// following New Moon of lunation k.
+func (o Observed) monthStart(k int) float64 {
+	jde := moonphase.New(2000 + float64(k)/12.3685)
+	jd := jde - deltat.Interp10A(jde).Day()
+	d0 := math.Floor(jd-.5) + .5
+	for i := 0.; i < 3; i++ {
+		y, mon, d := julian.JDToTime(d0 + i).Date()
+		if o.Rule(y, int(mon), d) {
+			return d0 + i + 1
+		}
+	}
+	return d0 + 3
+}
+
+// JD returns the julian day of 0h UT of the civil day corresponding to a
+// Moslem date.
+func (o Observed) JD(y, m, d int) (float64, error) {
+	if m < 1 || m > 12 || d < 1 || d > 30 {
+		return 0, ErrorInvalidDate
+	}
+	k := lunation(y, m)
+	jd := o.monthStart(k) + float64(d-1)
+	if d == 30 && jd >= o.monthStart(k+1) {
+		return 0, ErrorInvalidDate
+	}
+	return jd, nil
+}
+
+// Date returns the Moslem date of the civil day containing jd.
+func (o Observed) Date(jd float64) (y, m, d int, err error) {
+	jd = math.Floor(jd-.5) + .5
+	k := int(math.Floor((jd - 2451550.09766) / 29.530588861))
+	s := o.monthStart(k)
+	for s > jd {
+		k--
+		s = o.monthStart(k)
+	}
+	for {
+		s1 := o.monthStart(k + 1)
+		if s1 > jd {
+			break
+		}
+		k, s = k+1, s1
+	}
+	n := k + 17037
+	q := base.FloorDiv(n, 12)
+	return q + 1, n - 12*q + 1, int(jd-s) + 1, nil
+}
+
+// CrescentRule returns a VisibilityRule that reports a sighting when the
+// crescent seen from pos is in zone z of Yallop's criterion or better.
+// 以 Yallop 判据判断新月是否可见
+//
+// Argument e must be a valid Planet object for Earth.
+func CrescentRule(pos globe.Coord, e pp.Planet, z crescent.YallopZone) VisibilityRule {
+	return func(yr, mon, day int) bool {
+		c, err := crescent.At(yr, mon, day, pos, e)
+		return err == nil && c.Yallop() <= z
+	}
+}
+
+// ConjunctionRule returns a VisibilityRule that reports a sighting when,
+// at pos, New Moon occurs before sunset and the Moon sets after the Sun.
+// 日落前合朔且月落晚于日落
+//
+// This is the rule of the Umm al-Qura calendar since 1423 AH, with pos
+// at Mecca.  Argument e must be a valid Planet object for Earth.
+func ConjunctionRule(pos globe.Coord, e pp.Planet) VisibilityRule {
+	return func(yr, mon, day int) bool {
+		c, err := crescent.At(yr, mon, day, pos, e)
+		// age from a New Moon after sunset would be nearly a lunation
+		return err == nil && c.Age < unit.TimeFromDay(15) && c.Lag > 0
+	}
+}
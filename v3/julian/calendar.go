@@ -0,0 +1,141 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package julian
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Calendar is a calendar system of the Julian calendar until a date of
+// reform and the Gregorian calendar from that date.
+// 以改历日期区分儒略历与格里历的历法
+//
+// The reform came in 1582 in Rome, as assumed by JDToCalendar, but later
+// in many countries, in 1752 in Britain and its colonies for example.
+type Calendar struct {
+	Reform float64 // julian day of 0h of the first day of the Gregorian calendar
+}
+
+// NewCalendar returns a Calendar with a reform at 0h of the given date of
+// the Gregorian calendar, the first day on which the Gregorian calendar was
+// used.
+func NewCalendar(y, m, d int) Calendar {
+	return Calendar{CalendarGregorianToJD(y, m, float64(d))}
+}
+
+// Calendar systems.
+var (
+	Gregorian = Calendar{math.Inf(-1)}    // proleptic Gregorian calendar
+	Julian    = Calendar{math.Inf(1)}     // proleptic Julian calendar
+	Rome      = NewCalendar(1582, 10, 15) // reform of 1582 October 15
+	Britain   = NewCalendar(1752, 9, 14)  // reform of 1752 September 14
+)
+
+// ToJD converts a year, month, and day of month of the calendar system to
+// julian day.
+//
+// Dates omitted at the reform, 1582 October 5 to 14 in Rome for example,
+// are taken in the Julian calendar.
+func (c Calendar) ToJD(y, m int, d float64) float64 {
+	if jd := CalendarGregorianToJD(y, m, d); jd >= c.Reform {
+		return jd
+	}
+	return CalendarJulianToJD(y, m, d)
+}
+
+// FromJD returns the date in the calendar system of the given jd.
+func (c Calendar) FromJD(jd float64) (year, month int, day float64) {
+	return jdToCalendar(jd, jd >= c.Reform)
+}
+
+// LeapYear returns true if year y is a leap year in the calendar system.
+//
+// The Julian rule is used for years with a reform after February.
+func (c Calendar) LeapYear(y int) bool {
+	if CalendarGregorianToJD(y, 3, 1) < c.Reform {
+		return LeapYearJulian(y)
+	}
+	return LeapYearGregorian(y)
+}
+
+// YearDays returns the number of days in year y of the calendar system.
+//
+// The year of the reform is shortened by the days omitted, to 355 days in
+// 1752 in Britain for example.
+func (c Calendar) YearDays(y int) int {
+	return int(c.ToJD(y+1, 1, 1) - c.ToJD(y, 1, 1))
+}
+
+// YearBCAD returns the year of the BC or AD era for a year y in astronomical
+// numbering.
+// 天文纪年转公元前后纪年
+//
+// Functions of the library number years astronomically, where the year
+// before AD 1 is year 0, and the year before that is year -1.  Year 0 is
+// 1 BC, and year -1 is 2 BC.
+func YearBCAD(y int) (year int, bc bool) {
+	if y <= 0 {
+		return 1 - y, true
+	}
+	return y, false
+}
+
+// AstronomicalYear returns the astronomical year number of a year of the
+// BC era if bc is true, otherwise of the AD era.
+// 公元前后纪年转天文纪年
+func AstronomicalYear(year int, bc bool) int {
+	if bc {
+		return 1 - year
+	}
+	return year
+}
+
+// FormatYear formats year y of astronomical numbering in the BC or AD era,
+// as "44 BC" or "AD 1066".
+func FormatYear(y int) string {
+	if year, bc := YearBCAD(y); bc {
+		return strconv.Itoa(year) + " BC"
+	}
+	return "AD " + strconv.Itoa(y)
+}
+
+// ErrorInvalidYear is returned by ParseYear for an invalid year.
+var ErrorInvalidYear = errors.New("Invalid year")
+
+// ParseYear parses a year and returns it in astronomical numbering.
+//
+// A year followed by "BC" or "BCE" is of the BC era.  A year preceded or
+// followed by "AD", or followed by "CE", is of the AD era.  A signed
+// number alone, "-43" for example, is taken as already astronomical.
+func ParseYear(s string) (int, error) {
+	f := strings.Fields(strings.ToUpper(s))
+	var n string
+	bc := false
+	switch {
+	case len(f) == 1:
+		y, err := strconv.Atoi(f[0])
+		if err != nil {
+			return 0, ErrorInvalidYear
+		}
+		return y, nil
+	case len(f) != 2:
+		return 0, ErrorInvalidYear
+	case f[0] == "AD":
+		n = f[1]
+	case f[1] == "AD" || f[1] == "CE":
+		n = f[0]
+	case f[1] == "BC" || f[1] == "BCE":
+		n, bc = f[0], true
+	default:
+		return 0, ErrorInvalidYear
+	}
+	year, err := strconv.Atoi(n)
+	if err != nil || year < 1 || n[0] == '+' {
+		return 0, ErrorInvalidYear
+	}
+	return AstronomicalYear(year, bc), nil
+}
@@ -0,0 +1,114 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package julian
+
+import (
+	"math"
+	"time"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+)
+
+// JD2 is a julian day split in two parts for precision.
+// 分为整日与日内小数两部分的儒略日
+//
+// A single float64 julian day near the present resolves only about 20µs.
+// JD2 holds Day, the julian day of 0h, always ending in .5, and Frac, the
+// fraction of the day since 0h, in the range [0,1).  Use NewJD2 to obtain
+// these normalized values from arbitrary parts.
+type JD2 struct {
+	Day  float64
+	Frac float64
+}
+
+// NewJD2 returns the JD2 of the sum day + frac, normalized.
+//
+// Any split of a julian day may be given, for example a whole julian day
+// and 0, or J2000 and days since J2000.
+func NewJD2(day, frac float64) JD2 {
+	d := math.Floor(day - .5)
+	frac += day - .5 - d
+	f := math.Floor(frac)
+	return JD2{d + f + .5, frac - f}
+}
+
+// JD returns the julian day as a single float64.
+func (j JD2) JD() float64 {
+	return j.Day + j.Frac
+}
+
+// Add returns the julian day j + days.
+func (j JD2) Add(days float64) JD2 {
+	i, f := math.Modf(days)
+	return NewJD2(j.Day+i, j.Frac+f)
+}
+
+// AddDuration returns the julian day j + d.
+func (j JD2) AddDuration(d time.Duration) JD2 {
+	const day = 24 * time.Hour
+	return NewJD2(j.Day+float64(d/day), j.Frac+float64(d%day)/float64(day))
+}
+
+// Sub returns the difference j - k in days.
+func (j JD2) Sub(k JD2) float64 {
+	return (j.Day - k.Day) + (j.Frac - k.Frac)
+}
+
+// Century returns the number of Julian centuries since J2000.
+//
+// See base.J2000Century2.
+func (j JD2) Century() float64 {
+	return base.J2000Century2(j.Day, j.Frac)
+}
+
+// CalendarGregorianToJD2 converts a Gregorian year, month, day of month,
+// and fraction of the day to a JD2.
+// 格里历日期转两部分儒略日
+func CalendarGregorianToJD2(y, m, d int, frac float64) JD2 {
+	return NewJD2(CalendarGregorianToJD(y, m, float64(d)), frac)
+}
+
+// CalendarJulianToJD2 converts a Julian year, month, day of month, and
+// fraction of the day to a JD2.
+// 儒略历日期转两部分儒略日
+func CalendarJulianToJD2(y, m, d int, frac float64) JD2 {
+	return NewJD2(CalendarJulianToJD(y, m, float64(d)), frac)
+}
+
+// Calendar returns the calendar date and fraction of the day of j.
+//
+// As with JDToCalendar, the date is in either the Julian or Gregorian
+// calendar, as appropriate.
+// 两部分儒略日转公历日期
+func (j JD2) Calendar() (year, month, day int, frac float64) {
+	y, m, d := JDToCalendar(j.Day)
+	return y, m, int(d), j.Frac
+}
+
+// TimeToJD2 takes a Go time.Time and returns a JD2.
+//
+// The conversion keeps the full nanosecond resolution of the time.Time.
+// As with TimeToJD, any time zone offset is ignored and the time is treated
+// as UTC.
+// Go Time 类型转为两部分儒略日
+func TimeToJD2(t time.Time) JD2 {
+	ut := t.UTC()
+	y, m, d := ut.Date()
+	ns := ut.Sub(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	return JD2{
+		CalendarGregorianToJD(y, int(m), float64(d)),
+		float64(ns) / float64(24*time.Hour),
+	}
+}
+
+// Time returns the Go time.Time of j.
+//
+// The time is the nearest nanosecond, so that a time.Time converted by
+// TimeToJD2 is returned unchanged.
+// 两部分儒略日转为 Go Time 类型
+func (j JD2) Time() time.Time {
+	y, m, d := JDToCalendarGregorian(j.Day)
+	t := time.Date(y, time.Month(m), int(d), 0, 0, 0, 0, time.UTC)
+	return t.Add(time.Duration(math.Round(j.Frac * float64(24*time.Hour))))
+}
@@ -13,6 +13,11 @@
 //
 // See also related functions JulianToGregorian and GregorianToJulian in
 // package jm.
+//
+// Beyond the chapter, JDToCalendarGregorian and JDToCalendarJulian convert
+// to the proleptic calendars, and type Calendar handles a Gregorian reform
+// at dates other than that of 1582.  Years are numbered astronomically
+// throughout; see YearBCAD and FormatYear for the BC and AD eras.
 package julian
 
 import (
@@ -75,47 +80,45 @@ func LeapYearGregorian(y int) bool {
 // 儒略日转公历日期
 // 如果儒略日对应的格里历时间点在1582-10-15 12点之前，则转为儒略历日期
 // 如果儒略日对应的格里历时间点在1582-10-15 12点之后，则转为格里历日期
+//
+// For another date of reform see type Calendar.
 func JDToCalendar(jd float64) (year, month int, day float64) {
-	zf, f := math.Modf(jd + .5)
-	z := int64(zf)
-	a := z
-	// if z >= 2299151 { // typo 应该是2299161对应于现实格里历起始日1582-10-15中午12点
-	if z >= 2299161 { // typo 应该是2299161对应于现实格里历起始日1582-10-15中午12点
-		α := base.FloorDiv64(z*100-186721625, 3652425)
-		a = z + 1 + α - base.FloorDiv64(α, 4)
-	}
-	b := a + 1524
-	c := base.FloorDiv64(b*100-12210, 36525)
-	d := base.FloorDiv64(36525*c, 100)
-	e := int(base.FloorDiv64((b-d)*1e4, 306001))
-	// compute return values
-	day = float64(int(b-d)-base.FloorDiv(306001*e, 1e4)) + f
-	switch e {
-	default:
-		month = e - 1
-	case 14, 15:
-		month = e - 13
-	}
-	switch month {
-	default:
-		year = int(c) - 4716
-	case 1, 2:
-		year = int(c) - 4715
-	}
-	return
+	// 2299161对应于现实格里历起始日1582-10-15中午12点
+	return jdToCalendar(jd, jd+.5 >= 2299161)
 }
 
-// jdToCalendarGregorian returns the Gregorian calendar date for the given jd.
+// JDToCalendarGregorian returns the date in the proleptic Gregorian
+// calendar for the given jd.
 //
 // Note that it returns a Gregorian date even for dates before the start of
 // the Gregorian calendar.  The function is useful when working with Go
 // time.Time values because they are always based on the Gregorian calendar.
+// It is the inverse of CalendarGregorianToJD.
 // 始终转为格里历，忽略儒略历转格里历被扣除的那10天，即把1582-10-15 12点之前的日期也当成格里历算
-func jdToCalendarGregorian(jd float64) (year, month int, day float64) {
+func JDToCalendarGregorian(jd float64) (year, month int, day float64) {
+	return jdToCalendar(jd, true)
+}
+
+// JDToCalendarJulian returns the date in the proleptic Julian calendar for
+// the given jd.
+//
+// It returns a Julian date even for dates after the start of the Gregorian
+// calendar, and is the inverse of CalendarJulianToJD.
+// 始终转为儒略历
+func JDToCalendarJulian(jd float64) (year, month int, day float64) {
+	return jdToCalendar(jd, false)
+}
+
+// jdToCalendar returns the date for the given jd in the Gregorian calendar
+// if gregorian is true, otherwise in the Julian calendar.
+func jdToCalendar(jd float64, gregorian bool) (year, month int, day float64) {
 	zf, f := math.Modf(jd + .5)
 	z := int64(zf)
-	α := base.FloorDiv64(z*100-186721625, 3652425)
-	a := z + 1 + α - base.FloorDiv64(α, 4)
+	a := z
+	if gregorian {
+		α := base.FloorDiv64(z*100-186721625, 3652425)
+		a = z + 1 + α - base.FloorDiv64(α, 4)
+	}
 	b := a + 1524
 	c := base.FloorDiv64(b*100-12210, 36525)
 	d := base.FloorDiv64(36525*c, 100)
@@ -141,7 +144,7 @@ func jdToCalendarGregorian(jd float64) (year, month int, day float64) {
 // 儒略历转为格里历日期对应的 Go Time 类型
 func JDToTime(jd float64) time.Time {
 	// time.Time is always Gregorian
-	y, m, d := jdToCalendarGregorian(jd)
+	y, m, d := JDToCalendarGregorian(jd)
 	t := time.Date(y, time.Month(m), 0, 0, 0, 0, 0, time.UTC)
 	return t.Add(time.Duration(d * 24 * float64(time.Hour)))
 }
@@ -190,3 +190,131 @@ func ExampleJDToTime() {
 	// 1582-10-15 12:00:00 +0000 UTC
 	// 1582-10-14 12:00:00 +0000 UTC
 }
+
+func ExampleTimeToJD2() {
+	t := time.Date(2024, 3, 20, 3, 6, 4, 123456789, time.UTC)
+	j := julian.TimeToJD2(t)
+	fmt.Printf("%.1f %.15f\n", j.Day, j.Frac)
+	fmt.Println(j.Time())
+	fmt.Println(j.AddDuration(time.Nanosecond).Time())
+	// Output:
+	// 2460389.5 0.129214391860984
+	// 2024-03-20 03:06:04.123456789 +0000 UTC
+	// 2024-03-20 03:06:04.12345679 +0000 UTC
+}
+
+func TestJD2(t *testing.T) {
+	// time.Time round trip to the nanosecond
+	t0 := time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC)
+	for _, d := range []time.Duration{0, 1, 999, 86399999999999, -1,
+		1e6*time.Hour + 7, -1e6*time.Hour - 13} {
+		tm := t0.Add(d)
+		if r := julian.TimeToJD2(tm).Time(); !r.Equal(tm) {
+			t.Fatal(tm, r)
+		}
+	}
+	// normalization and arithmetic
+	j := julian.NewJD2(2446896, .30625)
+	if j.Day != 2446895.5 || math.Abs(j.Frac-.80625) > 1e-15 {
+		t.Fatal(j)
+	}
+	if k := j.Add(-1.5); k.Day != 2446894.5 || math.Abs(k.Frac-.30625) > 1e-15 {
+		t.Fatal(k)
+	}
+	if k := j.Add(1e4).Add(1e-9); k.Day-j.Day != 1e4 || math.Abs(k.Frac-j.Frac-1e-9) > 1e-15 {
+		t.Fatal(k)
+	}
+	if k := j.AddDuration(-20 * time.Hour); k.Day != 2446894.5 {
+		t.Fatal(k)
+	}
+	// calendar conversion
+	y, m, d, f := julian.CalendarJulianToJD2(333, 1, 27, .5).Calendar()
+	if y != 333 || m != 1 || d != 27 || f != .5 {
+		t.Fatal(y, m, d, f)
+	}
+	if jd := julian.CalendarGregorianToJD2(1957, 10, 4, .81).JD(); jd != 2436116.31 {
+		t.Fatal(jd)
+	}
+}
+
+func ExampleCalendar() {
+	// In Britain Wednesday 1752 September 2 was followed by Thursday
+	// September 14.
+	j := julian.Britain.ToJD(1752, 9, 2)
+	fmt.Println(julian.Britain.FromJD(j + 1))
+	fmt.Println(julian.JDToCalendarJulian(j + 1))
+	fmt.Println(julian.Britain.YearDays(1752))
+	fmt.Println(julian.Britain.LeapYear(1700), julian.Rome.LeapYear(1700))
+	// Output:
+	// 1752 9 14
+	// 1752 9 3
+	// 355
+	// true false
+}
+
+func ExampleFormatYear() {
+	fmt.Println(julian.FormatYear(-43), julian.FormatYear(0), julian.FormatYear(1066))
+	y, _ := julian.ParseYear("44 BC")
+	fmt.Println(y)
+	// Output:
+	// 44 BC 1 BC AD 1066
+	// -43
+}
+
+func TestCalendar(t *testing.T) {
+	for jd := 0.; jd < 2600000; jd += 97.25 {
+		// proleptic calendars are inverses
+		y, m, d := julian.JDToCalendarGregorian(jd)
+		if j := julian.CalendarGregorianToJD(y, m, d); math.Abs(j-jd) > 1e-9 {
+			t.Fatal("Gregorian", jd, j)
+		}
+		y, m, d = julian.JDToCalendarJulian(jd)
+		if j := julian.CalendarJulianToJD(y, m, d); math.Abs(j-jd) > 1e-9 {
+			t.Fatal("Julian", jd, j)
+		}
+		// Rome is JDToCalendar
+		y, m, d = julian.Rome.FromJD(jd)
+		y2, m2, d2 := julian.JDToCalendar(jd)
+		if y != y2 || m != m2 || d != d2 {
+			t.Fatal("Rome", jd, y, m, d, y2, m2, d2)
+		}
+		for _, c := range []julian.Calendar{julian.Gregorian, julian.Julian,
+			julian.Rome, julian.Britain} {
+			y, m, d := c.FromJD(jd)
+			if j := c.ToJD(y, m, d); math.Abs(j-jd) > 1e-9 {
+				t.Fatal(c, jd, j)
+			}
+		}
+	}
+	for _, y := range []int{-4712, -1, 0, 1, 1581, 1582, 1583, 1751, 1752, 2000} {
+		for _, c := range []julian.Calendar{julian.Rome, julian.Britain} {
+			n := 365
+			if c.LeapYear(y) {
+				n++
+			}
+			switch {
+			case y == 1582 && c == julian.Rome:
+				n -= 10
+			case y == 1752 && c == julian.Britain:
+				n -= 11
+			}
+			if d := c.YearDays(y); d != n {
+				t.Fatal(c, y, d, n)
+			}
+		}
+	}
+	for _, c := range []struct {
+		s string
+		y int
+	}{{"1 BC", 0}, {"2 bce", -1}, {"AD 1", 1}, {"1066 AD", 1066},
+		{"1066 CE", 1066}, {"-4712", -4712}, {"2024", 2024}} {
+		if y, err := julian.ParseYear(c.s); err != nil || y != c.y {
+			t.Fatal(c.s, y, err)
+		}
+	}
+	for _, s := range []string{"", "0 BC", "AD", "44 BC AD", "-44 BC", "BC 44"} {
+		if _, err := julian.ParseYear(s); err != julian.ErrorInvalidYear {
+			t.Fatal(s, err)
+		}
+	}
+}
@@ -23,7 +23,7 @@ import (
 //	ω2  Longitude of the System II central meridian of the illuminated disk,
 //	    as seen from Earth.
 //	P   Geocentric position angle of Jupiter's northern rotation pole.
-func Physical(jde float64, earth, jupiter *pp.V87Planet) (DS, DE, ω1, ω2, P unit.Angle) {
+func Physical(jde float64, earth, jupiter pp.Planet) (DS, DE, ω1, ω2, P unit.Angle) {
 	// Step 1.
 	d := jde - 2433282.5
 	T1 := d / base.JulianCentury
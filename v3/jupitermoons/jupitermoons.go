@@ -77,7 +77,7 @@ func Positions(jde float64) (pI, pII, pIII, pIV XY) {
 // High accuracy method based on theory "E5."  Results returned in
 // argument pos, which must not be nil.  Returned coordinates in units
 // of Jupiter radii.
-func E5(jde float64, earth, jupiter *pp.V87Planet, pos *[4]XY) {
+func E5(jde float64, earth, jupiter pp.Planet, pos *[4]XY) {
 	// variables assigned in following block
 	var λ0, β0, t float64
 	Δ := 5.
@@ -18,6 +18,7 @@ import (
 // Physical computes quantities for physical observations of Mars.
 //
 // Results:
+//
 //	DE  planetocentric declination of the Earth.
 //	DS  planetocentric declination of the Sun.
 //	ω   Areographic longitude of the central meridian, as seen from Earth.
@@ -26,7 +27,7 @@ import (
 //	d   Apparent diameter of Mars.
 //	q   Greatest defect of illumination.
 //	k   Illuminated fraction of the disk.
-func Physical(jde float64, earth, mars *pp.V87Planet) (DE, DS, ω, P, Q, d, q unit.Angle, k float64) {
+func Physical(jde float64, earth, mars pp.Planet) (DE, DS, ω, P, Q, d, q unit.Angle, k float64) {
 	// Step 1.
 	T := base.J2000Century(jde)
 	const p = math.Pi / 180
@@ -127,3 +128,26 @@ func Physical(jde float64, earth, mars *pp.V87Planet) (DE, DS, ω, P, Q, d, q un
 	q = d.Mul(1 - k)
 	return
 }
+
+// Ephemeris holds the results of Physical.
+//
+// Field tags give names for encoding/json.  Angles are encoded as radians.
+type Ephemeris struct {
+	DE          unit.Angle `json:"declEarth"`       // planetocentric declination of the Earth
+	DS          unit.Angle `json:"declSun"`         // planetocentric declination of the Sun
+	Omega       unit.Angle `json:"centralMeridian"` // ω, areographic longitude of the central meridian
+	P           unit.Angle `json:"posAngle"`        // position angle of the northern rotation pole
+	Q           unit.Angle `json:"defectPosAngle"`  // position angle of greatest defect of illumination
+	Diameter    unit.Angle `json:"diameter"`        // apparent diameter
+	Defect      unit.Angle `json:"defect"`          // greatest defect of illumination
+	Illuminated float64    `json:"illuminated"`     // illuminated fraction of the disk
+}
+
+// PhysicalEphemeris computes the quantities of Physical and returns them
+// as an Ephemeris.
+// 火星物理星历，结果以结构体返回
+func PhysicalEphemeris(jde float64, earth, mars pp.Planet) (e Ephemeris) {
+	e.DE, e.DS, e.Omega, e.P, e.Q, e.Diameter, e.Defect, e.Illuminated =
+		Physical(jde, earth, mars)
+	return
+}
@@ -0,0 +1,63 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package mars_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/mooncaker816/learnmeeus/v3/mars"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+)
+
+func TestPhysicalEphemeris(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := pp.NewKeplerian(pp.Mars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Example 42.a, p. 291.
+	const jde = 2448935.500683
+	DE, DS, ω, P, Q, d, q, k := mars.Physical(jde, e, m)
+	got := mars.PhysicalEphemeris(jde, e, m)
+	if got != (mars.Ephemeris{DE: DE, DS: DS, Omega: ω, P: P, Q: Q,
+		Diameter: d, Defect: q, Illuminated: k}) {
+		t.Fatal(got)
+	}
+	// the Keplerian theory is good to a few hundredths of a degree here
+	if math.Abs(got.DE.Deg()-12.44) > .1 || math.Abs(got.Illuminated-.9012) > .001 {
+		t.Error(got.DE.Deg(), got.Illuminated)
+	}
+	// angles encode as radians, the illuminated fraction as is
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r map[string]float64
+	if err := json.Unmarshal(b, &r); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{
+		"declEarth":       DE.Rad(),
+		"declSun":         DS.Rad(),
+		"centralMeridian": ω.Rad(),
+		"posAngle":        P.Rad(),
+		"defectPosAngle":  Q.Rad(),
+		"diameter":        d.Rad(),
+		"defect":          q.Rad(),
+		"illuminated":     k,
+	}
+	if len(r) != len(want) {
+		t.Fatal(string(b))
+	}
+	for key, v := range want {
+		if r[key] != v {
+			t.Error(key, r[key], v)
+		}
+	}
+}
@@ -32,7 +32,7 @@ var sI, cI = math.Sincos(_I.Rad())
 // Returned P is the the position angle of the Moon's axis of rotation.
 //
 // Returned l0, b0 are the selenographic coordinates of the Sun.
-func Physical(jde float64, earth *pp.V87Planet) (l, b, P, l0, b0 unit.Angle) {
+func Physical(jde float64, earth pp.Planet) (l, b, P, l0, b0 unit.Angle) {
 	λ, β, Δ := moonposition.Position(jde) // (λ without nutation)月亮的地心视黄经,黄纬
 	m := newMoon(jde)
 	l, b = m.lib(λ, β)
@@ -173,7 +173,7 @@ func (m *moon) pa(λ, β, b unit.Angle) unit.Angle {
 }
 
 //太阳的月心月面坐标
-func (m *moon) sun(λ, β unit.Angle, Δ float64, earth *pp.V87Planet) (l0, b0 unit.Angle) {
+func (m *moon) sun(λ, β unit.Angle, Δ float64, earth pp.Planet) (l0, b0 unit.Angle) {
 	λ0, _, R := solar.ApparentVSOP87(earth, m.jde)
 	ΔR := Δ / (R * base.AU)
 	λH := λ0 + math.Pi + unit.AngleFromDeg(57.296).Mul(ΔR*(β.Cos()*(λ0-λ).Sin()))
@@ -231,7 +231,7 @@ func SunAltitude(η, θ, l0, b0 unit.Angle) unit.Angle {
 // Moon, jde can be any date.
 //
 // Returned is the time of sunrise as a jde nearest the given jde.
-func Sunrise(η, θ unit.Angle, jde float64, earth *pp.V87Planet) float64 {
+func Sunrise(η, θ unit.Angle, jde float64, earth pp.Planet) float64 {
 	jde -= srCorr(η, θ, jde, earth)
 	return jde - srCorr(η, θ, jde, earth)
 }
@@ -243,13 +243,13 @@ func Sunrise(η, θ unit.Angle, jde float64, earth *pp.V87Planet) float64 {
 // Moon, jde can be any date.
 //
 // Returned is the time of sunset as a jde nearest the given jde.
-func Sunset(η, θ unit.Angle, jde float64, earth *pp.V87Planet) float64 {
+func Sunset(η, θ unit.Angle, jde float64, earth pp.Planet) float64 {
 	jde += srCorr(η, θ, jde, earth)
 	return jde + srCorr(η, θ, jde, earth)
 }
 
 // 太阳升起降落时间的修正量
-func srCorr(η, θ unit.Angle, jde float64, earth *pp.V87Planet) float64 {
+func srCorr(η, θ unit.Angle, jde float64, earth pp.Planet) float64 {
 	_, _, _, l0, b0 := Physical(jde, earth)
 	h := SunAltitude(η, θ, l0, b0)
 	return h.Deg() / 12.19075 / θ.Cos()
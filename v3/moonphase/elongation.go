@@ -0,0 +1,75 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package moonphase
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/iterate"
+	"github.com/mooncaker816/learnmeeus/v3/moonposition"
+	"github.com/mooncaker816/learnmeeus/v3/solar"
+	"github.com/soniakeys/unit"
+)
+
+// Elongation returns the geocentric elongation of the Moon from the Sun.
+// 月球的地心距角
+//
+// Result is in the range 0 to π.  The positions of the Moon and Sun are
+// computed with moonposition.Position and solar.True, with aberration of
+// the Sun.  Nutation affects both equally and is omitted.
+func Elongation(jde float64) unit.Angle {
+	λ, β, _ := moonposition.Position(jde)
+	s, _ := solar.True(base.J2000Century(jde))
+	λ0 := s - unit.AngleFromDeg(.00569)
+	// (48.2) p. 345
+	return unit.Angle(math.Acos(β.Cos() * (λ - λ0).Cos()))
+}
+
+// ErrorElongation is returned by ElongationAfter for an elongation out of
+// range or not reached within a year.
+var ErrorElongation = errors.New("Elongation not reached")
+
+// ElongationAfter returns the first time after jde that the Moon reaches
+// elongation ψ.
+// 计算 jde 之后月球到达某一距角的时刻
+//
+// If waxing is true the result is between New Moon and Full Moon, as for
+// an evening crescent.  Otherwise it is between Full Moon and New Moon.
+//
+// The Moon does not reach every elongation in every lunation.  Its
+// elongation at New Moon is about its latitude and so may be as much as
+// 5°; at Full Moon it may be as little as 175°.  Lunations that do not
+// reach ψ are skipped.
+//
+// The illuminated fraction of the disk is close to (1 - cos ψ) / 2, so an
+// elongation of about 20° gives a crescent 3% illuminated.  See package
+// moonillum for the exact relation.
+//
+// The time is found by binary search between times of New and Full Moon
+// and is good to the accuracy of moonposition.Position, a few seconds.
+func ElongationAfter(ψ unit.Angle, waxing bool, jde float64) (float64, error) {
+	if ψ <= 0 || ψ >= math.Pi {
+		return 0, ErrorElongation
+	}
+	f := func(jde float64) float64 { return (Elongation(jde) - ψ).Rad() }
+	it := NewIterator(jde)
+	t1 := jde
+	// principal phases of a year
+	for i := 0; i < 50; i++ {
+		p := it.Next()
+		if p.Phase != NewMoon && p.Phase != FullMoon {
+			continue
+		}
+		// t1 to p.JDE is waxing if it ends at Full Moon
+		if (p.Phase == FullMoon) == waxing {
+			if y1, y2 := f(t1), f(p.JDE); (y1 < 0) != (y2 < 0) {
+				return iterate.BinaryRoot(f, t1, p.JDE), nil
+			}
+		}
+		t1 = p.JDE
+	}
+	return 0, ErrorElongation
+}
@@ -0,0 +1,92 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package moonphase
+
+import (
+	"fmt"
+	"math"
+)
+
+// Phase identifies a principal phase of the Moon.
+type Phase int
+
+// Principal phases, in the order they occur within a lunation.
+const (
+	NewMoon      Phase = iota // 新月
+	FirstQuarter              // 上弦
+	FullMoon                  // 满月
+	LastQuarter               // 下弦
+)
+
+var phaseNames = [...]string{"new moon", "first quarter", "full moon",
+	"last quarter"}
+
+// String returns the name of the phase, "first quarter" for example.
+func (p Phase) String() string {
+	if p < 0 || int(p) >= len(phaseNames) {
+		return fmt.Sprintf("Phase(%d)", int(p))
+	}
+	return phaseNames[p]
+}
+
+// BrownOffset is the difference between Brown's lunation number and the
+// lunation number k of (49.2).  Brown's lunation 1 began with the New Moon
+// of 1923 January 17.
+const BrownOffset = 953
+
+// Event is a principal phase of the Moon.
+type Event struct {
+	Phase Phase
+	JDE   float64 // time of the phase
+	// Lunation is the integer part of k of (49.2), counting lunations from
+	// the New Moon of 2000 January 6.  A lunation begins with New Moon and
+	// includes the following quarters and Full Moon.
+	Lunation int
+	Brown    int // Brown's lunation number, Lunation + BrownOffset
+}
+
+// Iterator steps through principal phases of the Moon in order of time.
+type Iterator struct {
+	k float64 // lunation number of the next phase
+}
+
+// NewIterator returns an Iterator positioned at the first principal phase
+// at or after jde.
+func NewIterator(jde float64) *Iterator {
+	// mean phases are within a day of true phases, so start a quarter
+	// before the mean phase preceding jde.
+	k := math.Floor((jde-2451550.09766)/29.530588861*4)/4 - .25
+	for phase(k) < jde {
+		k += .25
+	}
+	return &Iterator{k}
+}
+
+// Next returns the next principal phase and advances the iterator.
+func (it *Iterator) Next() Event {
+	k := it.k
+	it.k += .25
+	l := math.Floor(k)
+	return Event{
+		Phase:    Phase(math.Round((k - l) * 4)),
+		JDE:      phase(k),
+		Lunation: int(l),
+		Brown:    int(l) + BrownOffset,
+	}
+}
+
+// Phases returns all principal phases of the Moon from jde1 up to jde2.
+// 计算时间段内的所有主要月相
+//
+// Phases are returned in order of time.  Times are computed with the
+// series of chapter 49 as for New, First, Full, and Last.
+func Phases(jde1, jde2 float64) (e []Event) {
+	for it := NewIterator(jde1); ; {
+		p := it.Next()
+		if p.JDE >= jde2 {
+			return
+		}
+		e = append(e, p)
+	}
+}
@@ -2,6 +2,10 @@
 // License: MIT
 
 // Moonphase: Chapter 49, Phases of the Moon
+//
+// Beyond the chapter, Phases and Iterator list principal phases in order
+// with their lunation numbers, and ElongationAfter finds the time the Moon
+// reaches any elongation from the Sun.
 package moonphase
 
 import (
@@ -69,8 +73,7 @@ func MeanLast(year float64) float64 {
 //
 // Year is a decimal year specifying a date.
 func New(year float64) float64 {
-	m := newMp(year, 0)
-	return mean(m.T) + m.nfc(&nc) + m.a()
+	return phase(snap(year, 0))
 }
 
 // First returns the jde of First Quarter Moon nearest the given date.
@@ -78,8 +81,7 @@ func New(year float64) float64 {
 //
 // Year is a decimal year specifying a date.
 func First(year float64) float64 {
-	m := newMp(year, .25)
-	return mean(m.T) + m.flc() + m.w() + m.a()
+	return phase(snap(year, .25))
 }
 
 // Full returns the jde of Full Moon nearest the given date.
@@ -87,8 +89,7 @@ func First(year float64) float64 {
 //
 // Year is a decimal year specifying a date.
 func Full(year float64) float64 {
-	m := newMp(year, .5)
-	return mean(m.T) + m.nfc(&fc) + m.a()
+	return phase(snap(year, .5))
 }
 
 // Last returns the jde of Last Quarter Moon nearest the given date.
@@ -96,7 +97,22 @@ func Full(year float64) float64 {
 //
 // Year is a decimal year specifying a date.
 func Last(year float64) float64 {
-	m := newMp(year, .75)
+	return phase(snap(year, .75))
+}
+
+// phase returns the jde of the phase with lunation number k.  The fraction
+// of k selects the phase, 0 for New Moon, .25 for First Quarter, .5 for
+// Full Moon, .75 for Last Quarter.
+func phase(k float64) float64 {
+	m := newMp(k)
+	switch k - math.Floor(k) {
+	case 0:
+		return mean(m.T) + m.nfc(&nc) + m.a()
+	case .25:
+		return mean(m.T) + m.flc() + m.w() + m.a()
+	case .5:
+		return mean(m.T) + m.nfc(&fc) + m.a()
+	}
 	return mean(m.T) + m.flc() - m.w() + m.a()
 }
 
@@ -108,9 +124,9 @@ type mp struct {
 
 const p = math.Pi / 180
 
-func newMp(y, q float64) *mp {
-	m := &mp{k: snap(y, q)} // 计算 k
-	m.T = m.k * ck          // (49.3) p. 350
+func newMp(k float64) *mp {
+	m := &mp{k: k}
+	m.T = m.k * ck // (49.3) p. 350
 	m.E = base.Horner(m.T, 1, -.002516, -.0000074)
 	m.M = base.Horner(m.T, 2.5534*p, 29.1053567*p/ck,
 		-.0000014*p, -.00000011*p)
@@ -5,8 +5,12 @@ package moonphase_test
 
 import (
 	"fmt"
+	"math"
+	"testing"
 
+	"github.com/mooncaker816/learnmeeus/v3/julian"
 	"github.com/mooncaker816/learnmeeus/v3/moonphase"
+	"github.com/soniakeys/unit"
 )
 
 func ExampleMeanNew() {
@@ -36,3 +40,76 @@ func ExampleLast() {
 	// Output:
 	// JDE = 2467636.49186
 }
+
+func TestPhases(t *testing.T) {
+	// 2017 August: last quarter 15th, new moon 21st (Brown lunation 1171),
+	// first quarter 29th.
+	jd1 := julian.CalendarGregorianToJD(2017, 8, 1)
+	jd2 := julian.CalendarGregorianToJD(2017, 9, 1)
+	p := moonphase.Phases(jd1, jd2)
+	want := []struct {
+		phase moonphase.Phase
+		day   int
+	}{
+		{moonphase.FullMoon, 7},
+		{moonphase.LastQuarter, 15},
+		{moonphase.NewMoon, 21},
+		{moonphase.FirstQuarter, 29},
+	}
+	if len(p) != len(want) {
+		t.Fatal(len(p))
+	}
+	for i, w := range want {
+		_, _, d := julian.JDToCalendar(p[i].JDE)
+		if p[i].Phase != w.phase || int(d) != w.day {
+			t.Error(p[i].Phase, d)
+		}
+	}
+	if p[2].Lunation != 218 || p[2].Brown != 1171 || p[1].Lunation != 217 {
+		t.Error(p[1].Lunation, p[2].Lunation, p[2].Brown)
+	}
+	// agrees with New and Last
+	if p[2].JDE != moonphase.New(2017.64) || p[1].JDE != moonphase.Last(2017.62) {
+		t.Error(p[2].JDE, p[1].JDE)
+	}
+	// iterator continues in order
+	it := moonphase.NewIterator(p[3].JDE)
+	if e := it.Next(); e != p[3] {
+		t.Error(e)
+	}
+	if e := it.Next(); e.Phase != moonphase.FullMoon || e.JDE < p[3].JDE+6 {
+		t.Error(e)
+	}
+}
+
+func ExamplePhase_String() {
+	fmt.Println(moonphase.FirstQuarter)
+	// Output:
+	// first quarter
+}
+
+func TestElongationAfter(t *testing.T) {
+	jde := moonphase.New(1977.13)
+	if ψ := moonphase.Elongation(jde); ψ.Deg() > 5.3 {
+		t.Error(ψ.Deg())
+	}
+	ψ := unit.AngleFromDeg(20)
+	j, err := moonphase.ElongationAfter(ψ, true, jde)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if j-jde < 1 || j-jde > 2 || math.Abs(moonphase.Elongation(j).Deg()-20) > 1e-6 {
+		t.Error(j-jde, moonphase.Elongation(j).Deg())
+	}
+	// the waning crescent is before the next New Moon
+	j, err = moonphase.ElongationAfter(ψ, false, jde)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := moonphase.New(1977.22); n-j < 1 || n-j > 2 {
+		t.Error(n - j)
+	}
+	if _, err := moonphase.ElongationAfter(0, true, jde); err != moonphase.ErrorElongation {
+		t.Error(err)
+	}
+}
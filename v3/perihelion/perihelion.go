@@ -135,7 +135,7 @@ var ea = []float64{-1.352, .061, .062, .029, .031}    // 地球远日点修正
 //
 // Result jde is the time of the event, r is the distance of the planet
 // from the Sun in AU.
-func Perihelion2(p int, y, d float64, v *pp.V87Planet) (jde, r float64) {
+func Perihelion2(p int, y, d float64, v pp.Planet) (jde, r float64) {
 	return ap2(p, y, d, v, false, pf)
 }
 
@@ -149,11 +149,11 @@ func Perihelion2(p int, y, d float64, v *pp.V87Planet) (jde, r float64) {
 //
 // Result jde is the time of the event, r is the distance of the planet
 // from the Sun in AU.
-func Aphelion2(p int, y, d float64, v *pp.V87Planet) (jde, r float64) {
+func Aphelion2(p int, y, d float64, v pp.Planet) (jde, r float64) {
 	return ap2(p, y, d, v, true, af)
 }
 
-func ap2(p int, y, d float64, v *pp.V87Planet, a bool, f func(float64) float64) (jde, r float64) {
+func ap2(p int, y, d float64, v pp.Planet, a bool, f func(float64) float64) (jde, r float64) {
 	j1 := ap(p, y, a, f)
 	if p != Neptune {
 		return ap2a(j1, d, a, v)
@@ -166,7 +166,7 @@ func ap2(p int, y, d float64, v *pp.V87Planet, a bool, f func(float64) float64)
 	return j2, r2
 }
 
-func ap2a(j1, d float64, a bool, v *pp.V87Planet) (jde, r float64) {
+func ap2a(j1, d float64, a bool, v pp.Planet) (jde, r float64) {
 	j0 := j1 - d
 	j2 := j1 + d
 	rr := make([]float64, 3)
@@ -0,0 +1,201 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package planetary
+
+import (
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/coord"
+	"github.com/mooncaker816/learnmeeus/v3/elliptic"
+	"github.com/mooncaker816/learnmeeus/v3/interp"
+	"github.com/mooncaker816/learnmeeus/v3/iterate"
+	"github.com/mooncaker816/learnmeeus/v3/nutation"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/soniakeys/unit"
+)
+
+// Longitude returns the apparent geocentric ecliptic longitude of a planet.
+// 行星的地心视黄经
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.
+//
+// The position is computed with elliptic.Position and converted to the
+// ecliptic with the true obliquity.
+func Longitude(p, e pp.Planet, jde float64) unit.Angle {
+	λ, _ := ecliptic(p, e, jde)
+	return λ
+}
+
+// ecliptic returns apparent geocentric ecliptic coordinates of a planet.
+func ecliptic(p, e pp.Planet, jde float64) (λ, β unit.Angle) {
+	α, δ := elliptic.Position(p, e, jde)
+	_, Δε := nutation.Nutation(jde)
+	sε, cε := (nutation.MeanObliquity(jde) + Δε).Sincos()
+	λ, β = coord.EqToEcl(α, δ, sε, cε)
+	return λ.Mod1(), β
+}
+
+// step is the interval in days at which longitudes are sampled, short
+// enough to separate the stations of Mercury.
+const step = 1.
+
+// rate returns a function giving the geocentric motion of p in longitude,
+// in radians per day.
+func rate(p, e pp.Planet) iterate.RootFunc {
+	const ε = .01
+	return func(jde float64) float64 {
+		d := Longitude(p, e, jde+ε) - Longitude(p, e, jde-ε)
+		return math.Remainder(d.Rad(), 2*math.Pi) / (2 * ε)
+	}
+}
+
+// Station is a time when the geocentric longitude of a planet is
+// stationary.
+type Station struct {
+	JDE        float64
+	Longitude  unit.Angle
+	Retrograde bool // true where retrograde motion begins, false where it ends
+}
+
+// Stations returns the stations of a planet from jde1 up to jde2.
+// 计算时段内行星的留
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.
+//
+// Longitudes are sampled daily.  A station is located with the extremum
+// of an interpolating quadratic, as by interp.Len3, then refined by binary
+// search for a zero of the rate of motion in longitude.  Stations are
+// flat, so the time is less certain than the longitude, perhaps to a
+// minute with full VSOP87 theory.  With planetposition.Keplerian, which
+// neglects perturbations, errors are of hours for Mars and the outer
+// planets.
+func Stations(p, e pp.Planet, jde1, jde2 float64) (s []Station) {
+	f := rate(p, e)
+	// unwrapped longitudes at t-step, t, t+step
+	y := make([]float64, 3)
+	y[1] = Longitude(p, e, jde1).Rad()
+	y[2] = y[1] + math.Remainder(Longitude(p, e, jde1+step).Rad()-y[1], 2*math.Pi)
+	for t := jde1 + step; t < jde2; t += step {
+		y[0], y[1] = y[1], y[2]
+		y[2] = y[1] + math.Remainder(Longitude(p, e, t+step).Rad()-y[1], 2*math.Pi)
+		if (y[1]-y[0])*(y[2]-y[1]) >= 0 {
+			continue
+		}
+		lo, hi := t-step, t+step
+		if d, err := interp.NewLen3(lo, hi, y); err == nil {
+			if x, _, err := d.Extremum(); err == nil &&
+				(f(x-step/8) < 0) != (f(x+step/8) < 0) {
+				lo, hi = x-step/8, x+step/8
+			}
+		}
+		j := iterate.BinaryRoot(f, lo, hi)
+		if j < jde1 || j >= jde2 {
+			continue
+		}
+		s = append(s, Station{
+			JDE:        j,
+			Longitude:  Longitude(p, e, j),
+			Retrograde: y[2] < y[1],
+		})
+	}
+	return
+}
+
+// Ingress is a time when the geocentric longitude of a planet crosses a
+// boundary.
+type Ingress struct {
+	JDE        float64
+	Longitude  unit.Angle // longitude of the boundary crossed
+	Retrograde bool       // true if crossed in retrograde motion
+}
+
+// Ingresses returns the times from jde1 up to jde2 when the apparent
+// geocentric longitude of a planet crosses one of the longitudes of bounds.
+// 计算时段内行星进入各宫或星座的时刻
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.
+//
+// If bounds is nil the boundaries of the signs of the zodiac, multiples of
+// 30°, are used.  For constellations, give the longitudes where their
+// boundaries cross the ecliptic.  The boundaries are defined for the
+// equinox of B1875, so these longitudes must be precessed to the equinox
+// of date.
+//
+// Each crossing is found by binary search between daily samples.  A planet
+// near a station may cross a boundary three times.
+func Ingresses(p, e pp.Planet, jde1, jde2 float64, bounds []unit.Angle) (in []Ingress) {
+	if bounds == nil {
+		for i := 0; i < 12; i++ {
+			bounds = append(bounds, unit.AngleFromDeg(float64(30*i)))
+		}
+	}
+	// g returns the signed distance from b, in the range -π to π
+	g := func(jde float64, b unit.Angle) float64 {
+		return math.Remainder((Longitude(p, e, jde) - b).Rad(), 2*math.Pi)
+	}
+	λ1 := Longitude(p, e, jde1)
+	for t := jde1; t < jde2; t += step {
+		t2 := math.Min(t+step, jde2)
+		λ2 := Longitude(p, e, t2)
+		for _, b := range bounds {
+			g1 := math.Remainder((λ1 - b).Rad(), 2*math.Pi)
+			g2 := math.Remainder((λ2 - b).Rad(), 2*math.Pi)
+			if (g1 < 0) == (g2 < 0) || math.Abs(g1-g2) > math.Pi {
+				continue
+			}
+			in = append(in, Ingress{
+				JDE: iterate.BinaryRoot(func(jde float64) float64 {
+					return g(jde, b)
+				}, t, t2),
+				Longitude:  b.Mod1(),
+				Retrograde: g2 < g1,
+			})
+		}
+		λ1 = λ2
+	}
+	return
+}
+
+// Retrograde is a period of retrograde motion of a planet, the retrograde
+// loop.
+type Retrograde struct {
+	Start, End Station // stations beginning and ending retrograde motion
+
+	// ShadowStart is the last time before Start that the planet reached
+	// the longitude of End.  ShadowEnd is the first time after End that
+	// it again reaches the longitude of Start.  Between them the planet
+	// passes each longitude of the loop three times.
+	ShadowStart, ShadowEnd float64
+}
+
+// RetrogradePeriods returns periods of retrograde motion of a planet that
+// begin from jde1 up to jde2.
+// 计算时段内行星的逆行
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.
+func RetrogradePeriods(p, e pp.Planet, jde1, jde2 float64) (r []Retrograde) {
+	// retrograde motion of Saturn and beyond lasts nearly half a year
+	s := Stations(p, e, jde1, jde2+200)
+	for i, st := range s {
+		if !st.Retrograde || st.JDE >= jde2 || i+1 == len(s) {
+			continue
+		}
+		end := s[i+1]
+		// the shadow lasts somewhat longer than the loop itself
+		d := 2*(end.JDE-st.JDE) + 10
+		rp := Retrograde{Start: st, End: end}
+		if in := Ingresses(p, e, st.JDE-d, st.JDE, []unit.Angle{end.Longitude}); len(in) > 0 {
+			rp.ShadowStart = in[len(in)-1].JDE
+		}
+		if in := Ingresses(p, e, end.JDE, end.JDE+d, []unit.Angle{st.Longitude}); len(in) > 0 {
+			rp.ShadowEnd = in[0].JDE
+		}
+		r = append(r, rp)
+	}
+	return
+}
@@ -3,7 +3,20 @@
 
 // Planetary: Chapter 36, The Calculation of some Planetary Phenomena.
 //
-// Incomplete: Some functions unimplemented for lack of test data.
+// The series for greatest elongations of Venus, stations of Mercury and
+// Venus, and the first station of Mars are not the book's.  They have the
+// form of the series of the chapter, with coefficients fitted to the
+// planetary theory; see the note with their coefficients.
+//
+// Functions RefineConj, RefineOpp, RefineElongation, and RefineStation
+// improve the results of the series with planetary positions from
+// elliptic.Position, giving the instant of the phenomenon to the accuracy
+// of the planetary theory used.  That is a few minutes with VSOP87, but
+// hours for Mars and the outer planets with planetposition.Keplerian.
+//
+// Beyond the chapter, Stations, RetrogradePeriods, and Ingresses search
+// numerically for these phenomena of any planet, using positions from
+// elliptic.Position rather than the mean series of Table 36.A.
 package planetary
 
 import (
@@ -74,6 +87,15 @@ func VenusInfConj(y float64) (jde float64) {
 	return ms(y, vicA, vicB)
 }
 
+// VenusSupConj returns the time of a superior conjunction of Venus.
+// 计算金星的上合日
+//
+// Result is time (as a jde) of the event nearest the given time (as a
+// decimal year.)
+func VenusSupConj(y float64) (jde float64) {
+	return ms(y, vscA, vscB)
+}
+
 // MarsOpp returns the time of an opposition of Mars.
 // 计算火星的冲日
 //
@@ -83,6 +105,15 @@ func MarsOpp(y float64) (jde float64) {
 	return ms(y, moA, moB)
 }
 
+// MarsConj returns the time of a conjunction of Mars.
+// 计算火星的合日
+//
+// Result is time (as a jde) of the event nearest the given time (as a
+// decimal year.)
+func MarsConj(y float64) (jde float64) {
+	return ms(y, mcA, mcB)
+}
+
 // SumA computes the sum of periodic terms with "additional angles"
 // 计算带额外周期项的周期项之和
 func sumA(T, M float64, c [][]float64, aa []caa) float64 {
@@ -179,7 +210,7 @@ func NeptuneConj(y float64) (jde float64) {
 
 // El computes time and elongation of a greatest elongation event.
 func el(y float64, a *ca, t, e [][]float64) (jde float64, elongation unit.Angle) {
-	J, M, T := mean(y, micA)
+	J, M, T := mean(y, a)
 	return J + sum(T, M, t), unit.AngleFromDeg(sum(T, M, e))
 }
 
@@ -201,6 +232,80 @@ func MercuryWestElongation(y float64) (jde float64, elongation unit.Angle) {
 	return el(y, micA, mwt, mwe)
 }
 
+// VenusEastElongation returns the time and elongation of a greatest eastern elongation of Venus.
+// 金星最大东角距
+//
+// Result is time (as a jde) of the event nearest the given time (as a
+// decimal year.)
+func VenusEastElongation(y float64) (jde float64, elongation unit.Angle) {
+	return el(y, vicA, vet, vee)
+}
+
+// VenusWestElongation returns the time and elongation of a greatest western elongation of Venus.
+// 金星最大西角距
+//
+// Result is time (as a jde) of the event nearest the given time (as a
+// decimal year.)
+func VenusWestElongation(y float64) (jde float64, elongation unit.Angle) {
+	return el(y, vicA, vwt, vwe)
+}
+
+// MercuryStation1 returns the time of the first station of Mercury, where
+// retrograde motion begins.
+// 计算水星的第一次留
+//
+// Result is time (as a jde) of the event nearest the inferior conjunction
+// nearest the given time (as a decimal year.)
+func MercuryStation1(y float64) (jde float64) {
+	return ms(y, micA, mes1)
+}
+
+// MercuryStation2 returns the time of the second station of Mercury, where
+// retrograde motion ends.
+// 计算水星的第二次留
+//
+// Result is time (as a jde) of the event nearest the inferior conjunction
+// nearest the given time (as a decimal year.)
+func MercuryStation2(y float64) (jde float64) {
+	return ms(y, micA, mes2)
+}
+
+// VenusStation1 returns the time of the first station of Venus, where
+// retrograde motion begins.
+// 计算金星的第一次留
+//
+// Result is time (as a jde) of the event nearest the inferior conjunction
+// nearest the given time (as a decimal year.)
+func VenusStation1(y float64) (jde float64) {
+	return ms(y, vicA, vs1)
+}
+
+// VenusStation2 returns the time of the second station of Venus, where
+// retrograde motion ends.
+// 计算金星的第二次留
+//
+// Result is time (as a jde) of the event nearest the inferior conjunction
+// nearest the given time (as a decimal year.)
+func VenusStation2(y float64) (jde float64) {
+	return ms(y, vicA, vs2)
+}
+
+// MarsStation1 returns the time of the first station of Mars, where
+// retrograde motion begins.
+// 计算火星的第一次留
+//
+// Result is time (as a jde) of the event nearest the opposition nearest
+// the given time (as a decimal year.)
+func MarsStation1(y float64) (jde float64) {
+	return ms(y, moA, ms1)
+}
+
+// MarsStation2 returns the time of the second station of Mars, where
+// retrograde motion ends.
+// 计算火星的第二次留
+//
+// Result is time (as a jde) of the event nearest the opposition nearest
+// the given time (as a decimal year.)
 func MarsStation2(y float64) (jde float64) {
 	J, M, T := mean(y, moA)
 	return J + sum(T, M, ms2)
@@ -564,3 +669,126 @@ var ms2 = [][]float64{
 	{-.0021, -.0016},
 	{-.1497, -.0006},
 }
+
+// The series below are not from the book.  They have the form of the
+// series above, with coefficients fitted by least squares to the phenomena
+// found by RefineElongation and RefineStation with planetposition.Keplerian
+// for the years 1000 to 3000.  The fitted series agree with those phenomena to
+// a few minutes for Mercury and Venus and to about two hours for Mars.
+// Fitted the same way, the series for the second station of Mars agrees
+// with Table 36.D to .005 day in every coefficient.
+
+// Venus east time correction
+// 金星最大东角距对平下合的时间修正的周期项系数
+var vet = [][]float64{
+	{-70.75, .0002, -.00001},
+	{1.0265, -.001, -.00001},
+	{.2761, -.006},
+	{-.0459, -.0023, .00003},
+	{.1662, -.0037, -.00003},
+	{.0029},
+	{-.0004, -.0001},
+}
+
+// Venus east elongation
+// 金星最大东角距的角度修正的周期项系数
+var vee = [][]float64{
+	{46.3188, .0001},
+	{.6901, -.0024},
+	{.6684, -.0045},
+	{.0308, -.0002},
+	{.0037, -.0001},
+	{0},
+	{-.0006},
+}
+
+// Venus west time correction
+// 金星最大西角距对平下合的时间修正的周期项系数
+var vwt = [][]float64{
+	{70.7569, 0, -.00001},
+	{1.1196, -.0025, -.00001},
+	{.4545, -.0066},
+	{.1335, .002, -.00004},
+	{-.0703, .0023, .00003},
+	{.0069},
+	{.001, .0001},
+}
+
+// Venus west elongation
+// 金星最大西角距的角度修正的周期项系数
+var vwe = [][]float64{
+	{46.3225},
+	{-.5361, -.0003, .00001},
+	{.3098, .0016, -.00001},
+	{-.0163},
+	{-.0075, .0001},
+	{-.0002},
+	{-.0002},
+}
+
+// Mercury Station 1
+var mes1 = [][]float64{
+	{-11.0726, .0003},
+	{-4.7388, .0023, .00002},
+	{-1.3178, -.0156},
+	{.2267, -.0046},
+	{.7194, .0013, -.00002},
+	{.0641, .0017, -.00001},
+	{-.1653, .0007, .00001},
+	{-.0396, -.0003},
+	{.0244, -.0005},
+	{.0131},
+	{.0009, .0002},
+}
+
+// Mercury Station 2
+var mes2 = [][]float64{
+	{11.1305, -.0001},
+	{-3.914, .0073, .00002},
+	{-3.3941, -.0128, .00001},
+	{.5232, -.0039, -.00001},
+	{.5931, .0039, -.00002},
+	{-.059, .0018},
+	{-.1732, -.0006, .00001},
+	{-.005, -.0007},
+	{.0476, -.0001},
+	{.0072, .0002},
+	{-.0113, .0001},
+}
+
+// Venus Station 1
+var vs1 = [][]float64{
+	{-21.065, .0002, -.00001},
+	{1.9394, -.0029, -.00001},
+	{1.0725, -.0103},
+	{.0397, -.0024},
+	{.131, -.0004, -.00003},
+	{-.0014, -.0002},
+	{.0097, -.0001},
+}
+
+// Venus Station 2
+var vs2 = [][]float64{
+	{21.0576, 0, -.00001},
+	{1.9909, -.0039, -.00001},
+	{-.0408, -.0077, .00001},
+	{.1353, -.0009, -.00004},
+	{.0309, .0019, -.00001},
+	{.0088, -.0001},
+	{.0038, .0001},
+}
+
+// Mars Station 1
+var ms1 = [][]float64{
+	{-37.0738, -.0013, .00001},
+	{-20.0559, .0237, .00008},
+	{14.532, .0499, -.00005},
+	{1.175, -.0176, -.00003},
+	{-4.2516, -.0077, .00005},
+	{.4875, .0075, -.00001},
+	{1.1106, -.0021, -.00003},
+	{-.3637, -.0019, .00001},
+	{-.1774, .0029, .00001},
+	{.1448, -.0002, -.00002},
+	{-.0402, -.0016, -.00001},
+}
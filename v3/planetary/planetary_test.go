@@ -12,7 +12,9 @@ import (
 	"github.com/mooncaker816/learnmeeus/v3/base"
 	"github.com/mooncaker816/learnmeeus/v3/julian"
 	"github.com/mooncaker816/learnmeeus/v3/planetary"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
 	"github.com/soniakeys/sexagesimal"
+	"github.com/soniakeys/unit"
 )
 
 func ExampleMercuryInfConj() {
@@ -55,6 +57,18 @@ func ExampleMercuryWestElongation() {
 	// 19°45′
 }
 
+func ExampleVenusEastElongation() {
+	// The greatest eastern elongation of Venus of 2020 March 24.
+	j, el := planetary.VenusEastElongation(2020.2)
+	y, m, df := julian.JDToCalendar(j)
+	d, f := math.Modf(df)
+	fmt.Printf("%d %s %d, at %dʰ\n", y, time.Month(m), int(d), int(f*24+.5))
+	fmt.Printf("%.1f°\n", el.Deg())
+	// Output:
+	// 2020 March 24, at 22ʰ
+	// 46.1°
+}
+
 func ExampleMarsStation2() {
 	// Example 36.d, p. 254
 	j := planetary.MarsStation2(1997.3)
@@ -91,3 +105,152 @@ func Test255(t *testing.T) {
 		}
 	}
 }
+
+// keplerian returns the Keplerian theory of planet ibody.
+func keplerian(t *testing.T, ibody int) *pp.Keplerian {
+	p, err := pp.NewKeplerian(ibody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestRetrogradePeriods(t *testing.T) {
+	// Mars stations of 2020, Sep 9 22ʰ22ᵐ and Nov 14 0ʰ36ᵐ UT, from
+	// VSOP87.  The Keplerian theory is good to a few hours.
+	e := keplerian(t, pp.Earth)
+	p := keplerian(t, pp.Mars)
+	j1 := julian.CalendarGregorianToJD(2020, 1, 1)
+	r := planetary.RetrogradePeriods(p, e, j1, j1+366)
+	if len(r) != 1 {
+		t.Fatal(len(r), "periods")
+	}
+	start := julian.CalendarGregorianToJD(2020, 9, 9.93)
+	end := julian.CalendarGregorianToJD(2020, 11, 14.03)
+	if math.Abs(r[0].Start.JDE-start) > .25 || math.Abs(r[0].End.JDE-end) > .25 {
+		t.Error(r[0].Start.JDE-start, r[0].End.JDE-end)
+	}
+	if !(r[0].ShadowStart < r[0].Start.JDE && r[0].End.JDE < r[0].ShadowEnd) {
+		t.Error("shadow", r[0])
+	}
+	if l := planetary.Longitude(p, e, r[0].ShadowStart); math.Abs((l - r[0].End.Longitude).Rad()) > 1e-9 {
+		t.Error("shadow longitude", l.Deg(), r[0].End.Longitude.Deg())
+	}
+}
+
+func ExampleIngresses() {
+	// Mercury in 2023 December enters Capricorn, then returns to
+	// Sagittarius in retrograde motion.
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	p, err := pp.NewKeplerian(pp.Mercury)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	j1 := julian.CalendarGregorianToJD(2023, 11, 1)
+	for _, in := range planetary.Ingresses(p, e, j1, j1+61, nil) {
+		y, m, d := julian.JDToCalendar(in.JDE)
+		fmt.Printf("%d %s %2d %3.0f° %t\n", y, time.Month(m), int(d), in.Longitude.Deg(), in.Retrograde)
+	}
+	// Output:
+	// 2023 November 10 240° false
+	// 2023 December  1 270° false
+	// 2023 December 23 270° true
+}
+
+func ExampleVenusSupConj() {
+	// The superior conjunction of Venus of 2021 March 26.
+	j := planetary.VenusSupConj(2021.2)
+	y, m, df := julian.JDToCalendar(j)
+	fmt.Printf("%d %s %d\n", y, time.Month(m), int(df))
+	// Output:
+	// 2021 March 26
+}
+
+func TestRefine(t *testing.T) {
+	// Cross check series of the chapter against the refinement with
+	// positions of the Keplerian theory.  Perturbations neglected by the
+	// Keplerian theory limit the check to the inner planets and Jupiter.
+	e := keplerian(t, pp.Earth)
+	for _, tc := range []struct {
+		ibody int
+		f     func(float64) float64
+		opp   bool
+		hours float64
+	}{
+		{pp.Mercury, planetary.MercuryInfConj, false, 1},
+		{pp.Mercury, planetary.MercurySupConj, false, 1},
+		{pp.Venus, planetary.VenusInfConj, false, 1},
+		{pp.Venus, planetary.VenusSupConj, false, 1},
+		{pp.Mars, planetary.MarsOpp, true, 3},
+		{pp.Mars, planetary.MarsConj, false, 3},
+		{pp.Jupiter, planetary.JupiterOpp, true, 3},
+		{pp.Jupiter, planetary.JupiterConj, false, 3},
+	} {
+		p := keplerian(t, tc.ibody)
+		for y := 1990.; y < 2030; y += 1.3 {
+			j := tc.f(y)
+			refine := planetary.RefineConj
+			if tc.opp {
+				refine = planetary.RefineOpp
+			}
+			r, err := refine(p, e, j)
+			if err != nil || math.Abs(r-j)*24 > tc.hours {
+				t.Error(tc.ibody, y, (r-j)*24, err)
+			}
+		}
+	}
+	for _, tc := range []struct {
+		ibody int
+		f     func(float64) (float64, unit.Angle)
+	}{
+		{pp.Mercury, planetary.MercuryEastElongation},
+		{pp.Mercury, planetary.MercuryWestElongation},
+		{pp.Venus, planetary.VenusEastElongation},
+		{pp.Venus, planetary.VenusWestElongation},
+	} {
+		p := keplerian(t, tc.ibody)
+		for y := 1990.; y < 2030; y += .37 {
+			j, el := tc.f(y)
+			r, el2, err := planetary.RefineElongation(p, e, j)
+			if err != nil || math.Abs(r-j)*24 > 1 || math.Abs((el2-el).Min()) > 1 {
+				t.Error(tc.ibody, y, (r-j)*24, (el2 - el).Min(), err)
+			}
+		}
+	}
+	for _, tc := range []struct {
+		ibody int
+		f     func(float64) float64
+		hours float64
+	}{
+		{pp.Mercury, planetary.MercuryStation1, 1},
+		{pp.Mercury, planetary.MercuryStation2, 1},
+		{pp.Venus, planetary.VenusStation1, 1},
+		{pp.Venus, planetary.VenusStation2, 1},
+		{pp.Mars, planetary.MarsStation1, 3},
+		{pp.Mars, planetary.MarsStation2, 3},
+	} {
+		p := keplerian(t, tc.ibody)
+		for y := 1990.; y < 2030; y += .37 {
+			j := tc.f(y)
+			r, err := planetary.RefineStation(p, e, j)
+			if err != nil || math.Abs(r-j)*24 > tc.hours {
+				t.Error(tc.ibody, y, (r-j)*24, err)
+			}
+		}
+	}
+	// stations bound the retrograde motion about opposition.  The first
+	// station of 2020 was at Sep 9 22ʰ22ᵐ UT, as in TestRetrogradePeriods.
+	s1, o, s2 := planetary.MarsStation1(2020.8), planetary.MarsOpp(2020.8),
+		planetary.MarsStation2(2020.8)
+	if !(s1 < o && o < s2) {
+		t.Error(s1, o, s2)
+	}
+	if d := s1 - julian.CalendarGregorianToJD(2020, 9, 9.93); math.Abs(d) > .25 {
+		t.Error("Mars station 1", d)
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package planetary
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/iterate"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/mooncaker816/learnmeeus/v3/solar"
+	"github.com/soniakeys/unit"
+)
+
+// ErrorNotFound is returned by the Refine functions when the phenomenon is
+// not found near the estimate.
+var ErrorNotFound = errors.New("Phenomenon not found near estimate")
+
+// root finds a root of f near jde, widening the search until a change of
+// sign is found.
+func root(f iterate.RootFunc, jde float64) (float64, error) {
+	for _, d := range []float64{2, 10, 30} {
+		if (f(jde-d) < 0) != (f(jde+d) < 0) {
+			return iterate.BinaryRoot(f, jde-d, jde+d), nil
+		}
+	}
+	return 0, ErrorNotFound
+}
+
+// elongation returns the geocentric elongation of a planet from the Sun.
+func elongation(p, e pp.Planet, jde float64) unit.Angle {
+	λ, β := ecliptic(p, e, jde)
+	λ0, β0, _ := solar.ApparentVSOP87(e, jde)
+	return unit.Angle(math.Acos(β.Sin()*β0.Sin() + β.Cos()*β0.Cos()*(λ-λ0).Cos()))
+}
+
+// RefineConj returns the time of a conjunction of a planet with the Sun
+// in ecliptic longitude, nearest an estimate.
+// 以行星位置精确计算合日
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.  Argument jde is an
+// estimate, as from MercuryInfConj or SaturnConj for example.
+func RefineConj(p, e pp.Planet, jde float64) (float64, error) {
+	return root(func(jde float64) float64 {
+		λ0, _, _ := solar.ApparentVSOP87(e, jde)
+		return math.Remainder((Longitude(p, e, jde) - λ0).Rad(), 2*math.Pi)
+	}, jde)
+}
+
+// RefineOpp returns the time of an opposition of a planet to the Sun in
+// ecliptic longitude, nearest an estimate.
+// 以行星位置精确计算冲日
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.  Argument jde is an
+// estimate, as from MarsOpp for example.
+func RefineOpp(p, e pp.Planet, jde float64) (float64, error) {
+	return root(func(jde float64) float64 {
+		λ0, _, _ := solar.ApparentVSOP87(e, jde)
+		return math.Remainder((Longitude(p, e, jde)-λ0).Rad()-math.Pi, 2*math.Pi)
+	}, jde)
+}
+
+// RefineElongation returns the time and elongation of a greatest
+// elongation of a planet nearest an estimate.
+// 以行星位置精确计算最大角距
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.  Argument jde is an
+// estimate, as from MercuryEastElongation or VenusWestElongation for
+// example.
+func RefineElongation(p, e pp.Planet, jde float64) (float64, unit.Angle, error) {
+	const ε = .01
+	j, err := root(func(jde float64) float64 {
+		return (elongation(p, e, jde+ε) - elongation(p, e, jde-ε)).Rad()
+	}, jde)
+	if err != nil {
+		return 0, 0, err
+	}
+	return j, elongation(p, e, j), nil
+}
+
+// RefineStation returns the time of a station in longitude of a planet
+// nearest an estimate.
+// 以行星位置精确计算留
+//
+// Argument p must be a valid Planet object for the observed planet.
+// Argument e must be a valid Planet object for Earth.  Argument jde is an
+// estimate, as from MercuryStation1 or MarsStation2 for example.
+func RefineStation(p, e pp.Planet, jde float64) (float64, error) {
+	return root(rate(p, e), jde)
+}
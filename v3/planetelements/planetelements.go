@@ -127,7 +127,11 @@ func Mean(p int, jde float64, e *Elements) {
 	e.Axis = base.Horner(T, c.a...)
 	e.Ecc = base.Horner(T, c.e...)
 	e.Inc = unit.AngleFromDeg(base.Horner(T, c.i...))
-	e.Node = unit.AngleFromDeg(base.Horner(T, c.Ω...))
+	if c.Ω != nil { // Earth has no node of date
+		e.Node = unit.AngleFromDeg(base.Horner(T, c.Ω...))
+	} else {
+		e.Node = 0
+	}
 	e.Peri = unit.AngleFromDeg(base.Horner(T, c.ϖ...))
 }
 
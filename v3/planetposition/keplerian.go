@@ -0,0 +1,108 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package planetposition
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/coord"
+	"github.com/mooncaker816/learnmeeus/v3/kepler"
+	pe "github.com/mooncaker816/learnmeeus/v3/planetelements"
+	"github.com/mooncaker816/learnmeeus/v3/precess"
+	"github.com/soniakeys/unit"
+)
+
+// Planet is the position contract satisfied by V87Planet.
+//
+// Functions in other packages that need positions of a planet accept a
+// Planet, so that full or truncated VSOP87 series can be used.  The
+// accuracy of results is that of the Planet used.  Keplerian also
+// satisfies Planet, but only at its own, much lower, accuracy.
+type Planet interface {
+	// Position2000 returns heliocentric longitude, latitude, and range
+	// in AU for the dynamical equinox and ecliptic J2000.
+	Position2000(jde float64) (L, B unit.Angle, R float64)
+	// Position returns heliocentric longitude, latitude, and range
+	// in AU for the equinox and ecliptic of date.
+	Position(jde float64) (L, B unit.Angle, R float64)
+}
+
+// Keplerian computes approximate planetary positions from unperturbed
+// Keplerian orbits, without external data files.
+// 内置的开普勒轨道近似行星位置，无需 VSOP87 数据文件
+//
+// Positions are computed from the mean orbital elements of chapter 31,
+// Table 31.A, p. 212, by solving Kepler's equation.  Periodic perturbations
+// are neglected.  Errors are typically under a minute of arc for the inner
+// planets and may reach several tenths of a degree for Jupiter and Saturn,
+// which perturb each other strongly.  Ranges are similarly approximate.
+//
+// Keplerian is not the abridged VSOP87 theory of Appendix III, which this
+// package does not provide, and it is not offered in its place.  It does
+// not give the accuracy documented for VSOP87 by functions that accept a
+// Planet.  The times of solstice.March2 for example are good only to a few
+// minutes.  It serves for tests and rough work.  Use V87Planet, full or
+// truncated, where the book's accuracy is needed.
+type Keplerian struct {
+	ibody int
+}
+
+// NewKeplerian constructs a Keplerian object for a planet.
+//
+// Argument ibody should be one of the planet constants.
+func NewKeplerian(ibody int) (*Keplerian, error) {
+	if ibody < 0 || ibody >= nPlanets {
+		return nil, errors.New("Invalid planet.")
+	}
+	return &Keplerian{ibody}, nil
+}
+
+// Position returns ecliptic position of a planet at equinox and ecliptic
+// of date.
+//
+// Argument jde is the date for which positions are desired.
+//
+//	L is heliocentric longitude.
+//	B is heliocentric latitude.
+//	R is heliocentric range in AU.
+func (a *Keplerian) Position(jde float64) (L, B unit.Angle, R float64) {
+	var e pe.Elements
+	pe.Mean(a.ibody, jde, &e)
+	M := e.Lon - e.Peri
+	E, err := kepler.Kepler2b(e.Ecc, M, 15)
+	if err != nil {
+		E = kepler.Kepler3(e.Ecc, M)
+	}
+	ν := kepler.True(E, e.Ecc)
+	R = kepler.Radius(E, e.Ecc, e.Axis)
+	// argument of latitude
+	u := e.Peri - e.Node + ν
+	su, cu := u.Sincos()
+	si, ci := e.Inc.Sincos()
+	L = unit.Angle(unit.PMod(e.Node.Rad()+math.Atan2(ci*su, cu), 2*math.Pi))
+	B = unit.Angle(math.Asin(si * su))
+	return
+}
+
+// Position2000 returns ecliptic position of a planet for the equinox and
+// ecliptic J2000.
+//
+// Argument jde is the date for which positions are desired.
+//
+//	L is heliocentric longitude.
+//	B is heliocentric latitude.
+//	R is heliocentric range in AU.
+func (a *Keplerian) Position2000(jde float64) (L, B unit.Angle, R float64) {
+	L, B, R = a.Position(jde)
+	eclFrom := &coord.Ecliptic{
+		Lat: B,
+		Lon: L,
+	}
+	eclTo := &coord.Ecliptic{}
+	epochFrom := base.JDEToJulianYear(jde)
+	precess.EclipticPosition(eclFrom, eclTo, epochFrom, 2000, 0, 0)
+	return eclTo.Lon, eclTo.Lat, R
+}
@@ -11,9 +11,15 @@
 // and as the appendix is rather large, retyping it by hand is problematic.
 // The full VSOP87 data set on the other hand is freely downloadable from
 // the internet, so I implement here code that can use that data directly.
+// For the same reason a compiled-in Appendix III theory, giving positions
+// of the book's accuracy without data files, is not provided, and requests
+// for one are declined until the coefficients can be checked against a
+// machine readable source.  Type Keplerian, described with its
+// declaration, is a rough approximation and not a replacement for it.
 //
-// 2.  The formula for accuracy of results is not implemented.  It is
-// not needed for full VSOP87 solutions.
+// 2.  The formula for accuracy of results is used only for truncated
+// series.  See V87Planet.Truncate.  It is not needed for full VSOP87
+// solutions.
 //
 // 3.  Polynomial expressions are not implemented.  Again, implementation
 // would involve typing rather large tables of numbers with associated
@@ -23,6 +29,8 @@ package planetposition
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"io/ioutil"
 	"math"
 	"os"
@@ -49,14 +57,24 @@ const (
 	nPlanets // sad practicality
 )
 
-// parallel arrays, indexed by planet constants.
+// Additional body constants suitable for the second argument to LoadVariant.
+//
+// EMB, the Earth-Moon barycenter, is available in VSOP87A only.
+// Sun is available in VSOP87E only.
+const (
+	EMB = nPlanets + iota
+	Sun
+	nBodies
+)
+
+// parallel arrays, indexed by planet and body constants.
 var (
-	// extensions of VSOP87B files
-	ext = [nPlanets]string{
-		"mer", "ven", "ear", "mar", "jup", "sat", "ura", "nep"}
+	// extensions of VSOP87 files
+	ext = [nBodies]string{
+		"mer", "ven", "ear", "mar", "jup", "sat", "ura", "nep", "emb", "sun"}
 
-	// planet names as found in VSOP87B files
-	b7 = [nPlanets]string{
+	// planet names as found in VSOP87 files
+	b7 = [nBodies]string{
 		"MERCURY",
 		"VENUS  ",
 		"EARTH  ",
@@ -65,6 +83,8 @@ var (
 		"SATURN ",
 		"URANUS ",
 		"NEPTUNE",
+		"EMB    ",
+		"SUN    ",
 	}
 )
 
@@ -77,7 +97,8 @@ type coeff [6][]abc
 // V87Planet holds VSOP87 coefficients for computing planetary
 // positions in spherical coorditates.
 type V87Planet struct {
-	l, b, r coeff
+	l, b, r    coeff
+	tl, tb, tr truncation // zero values for full series
 }
 
 // code tested with version 2.  other versions unknown.
@@ -109,25 +130,65 @@ func LoadPlanetPath(ibody int, path string) (*V87Planet, error) {
 	if err != nil {
 		return nil, err
 	}
+	return loadPlanetData(ibody, data)
+}
+
+// LoadPlanetFS constructs a V87Planet object from a VSOP87 file in a
+// file system.
+// 从文件系统（如 embed.FS）中读取 VSOP87B 文件
+//
+// Argument ibody should be one of the planet constants; fsys should hold
+// the VSOP87B files at its root, under their original names such as
+// "VSOP87B.ear".  For files embedded in a subdirectory, use fs.Sub to
+// obtain a file system rooted at that directory.
+func LoadPlanetFS(ibody int, fsys fs.FS) (*V87Planet, error) {
+	if ibody < 0 || ibody >= nPlanets {
+		return nil, errors.New("Invalid planet.")
+	}
+	data, err := fs.ReadFile(fsys, "VSOP87B."+ext[ibody])
+	if err != nil {
+		return nil, err
+	}
+	return loadPlanetData(ibody, data)
+}
+
+// LoadPlanetReader constructs a V87Planet object from VSOP87 data read
+// from r.
+// 从 io.Reader 中读取 VSOP87B 数据
+//
+// Argument ibody should be one of the planet constants.  The data read
+// must be the contents of the VSOP87B file for that planet.  Version and
+// body are checked just as they are for files loaded by LoadPlanet.
+func LoadPlanetReader(ibody int, r io.Reader) (*V87Planet, error) {
+	if ibody < 0 || ibody >= nPlanets {
+		return nil, errors.New("Invalid planet.")
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return loadPlanetData(ibody, data)
+}
+
+func loadPlanetData(ibody int, data []byte) (*V87Planet, error) {
 	v := &V87Planet{}
 	lines := strings.Split(string(data), "\n")
-	n := 0
-	n, err = v.l.parse('1', ibody, lines, n, false)
+	n, err := v.l.parse('1', fileVersion, ibody, lines, 0, false)
 	if err != nil {
 		return nil, err
 	}
-	n, err = v.b.parse('2', ibody, lines, n, false)
+	n, err = v.b.parse('2', fileVersion, ibody, lines, n, false)
 	if err != nil {
 		return nil, err
 	}
-	n, err = v.r.parse('3', ibody, lines, n, true)
+	n, err = v.r.parse('3', fileVersion, ibody, lines, n, true)
 	if err != nil {
 		return nil, err
 	}
 	return v, nil
 }
 
-func (c *coeff) parse(ic byte, ibody int, lines []string, n int, au bool) (int, error) {
+func (c *coeff) parse(ic, version byte, ibody int, lines []string, n int, au bool) (int, error) {
 	var cbuf [2047]abc
 	for n < len(lines) {
 		line := lines[n]
@@ -137,9 +198,9 @@ func (c *coeff) parse(ic byte, ibody int, lines []string, n int, au bool) (int,
 		if line[41] != ic {
 			break
 		}
-		if iv := line[17]; iv != fileVersion {
+		if iv := line[17]; iv != version {
 			return n, fmt.Errorf("Line %d: expected version %c, "+
-				"found %c.", n+1, fileVersion, iv)
+				"found %c.", n+1, version, iv)
 		}
 		if bo := line[22:29]; bo != b7[ibody] {
 			return n, fmt.Errorf("Line %d: expected body %s, "+
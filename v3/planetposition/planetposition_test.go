@@ -0,0 +1,273 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package planetposition_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+)
+
+// vsopLine returns a 132 column line with fields placed at the given
+// columns.
+func vsopLine(fields map[int]string) string {
+	b := []byte(strings.Repeat(" ", 132))
+	for col, f := range fields {
+		copy(b[col:], f)
+	}
+	return string(b)
+}
+
+// vsopSeries is a series of terms for variable iv in power it of time.
+type vsopSeries struct {
+	iv, it byte
+	terms  [][3]float64 // amplitude, phase, frequency
+}
+
+// vsopFile formats series in the layout of the VSOP87 files.
+func vsopFile(version byte, body string, series ...vsopSeries) string {
+	var lines []string
+	for _, s := range series {
+		lines = append(lines, vsopLine(map[int]string{
+			1:  "VSOP87 VERSION",
+			17: string(version),
+			22: body,
+			32: "VARIABLE",
+			41: string(s.iv),
+			53: "*T**",
+			59: string(s.it),
+			60: fmt.Sprintf("%7d", len(s.terms)),
+			68: "TERMS",
+		}))
+		for _, t := range s.terms {
+			lines = append(lines, vsopLine(map[int]string{
+				79:  fmt.Sprintf("%18.11f", t[0]),
+				98:  fmt.Sprintf("%13.11f", t[1]),
+				111: fmt.Sprintf("%20.11f", t[2]),
+			}))
+		}
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// vsopB formats a minimal VSOP87B-like file.  Each variable gets a single
+// constant term in T⁰, and L gets an additional one term series in T¹.
+func vsopB(version byte, body string, l0, l1, b0, r0 float64) string {
+	return vsopFile(version, body,
+		vsopSeries{'1', '0', [][3]float64{{l0, 0, 0}}},
+		vsopSeries{'1', '1', [][3]float64{{l1, 0, 0}}},
+		vsopSeries{'2', '0', [][3]float64{{b0, 0, 0}}},
+		vsopSeries{'3', '0', [][3]float64{{r0, 0, 0}}})
+}
+
+func TestLoadPlanetReader(t *testing.T) {
+	f := vsopB('2', "MARS   ", 5, 1, .1, 1.5)
+	p, err := pp.LoadPlanetReader(pp.Mars, strings.NewReader(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	L, B, R := p.Position2000(base.J2000)
+	if L != 5 || B != .1 || R != 1.5 {
+		t.Fatal(L, B, R)
+	}
+	// one thousand years later, L1 has added one radian.
+	L, _, _ = p.Position2000(base.J2000 + 365250)
+	if math.Abs(L.Rad()-6) > 1e-12 {
+		t.Fatal(L)
+	}
+}
+
+func TestLoadPlanetFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"VSOP87B.mar": {Data: []byte(vsopB('2', "MARS   ", 5, 1, .1, 1.5))},
+	}
+	p, err := pp.LoadPlanetFS(pp.Mars, fsys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, R := p.Position2000(base.J2000); R != 1.5 {
+		t.Fatal(R)
+	}
+	if _, err := pp.LoadPlanetFS(pp.Venus, fsys); err == nil {
+		t.Fatal("expected error for missing file")
+	}
+}
+
+func TestLoadPlanetReaderChecks(t *testing.T) {
+	for _, tc := range []struct {
+		version byte
+		body    string
+		want    string
+	}{
+		{'1', "MARS   ", "expected version 2"},
+		{'2', "VENUS  ", "expected body MARS"},
+	} {
+		f := vsopB(tc.version, tc.body, 5, 1, .1, 1.5)
+		_, err := pp.LoadPlanetReader(pp.Mars, strings.NewReader(f))
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("got %v, want error containing %q", err, tc.want)
+		}
+	}
+	if _, err := pp.LoadPlanetReader(-1, strings.NewReader("")); err == nil {
+		t.Error("expected error for invalid planet")
+	}
+}
+
+func TestLoadVariantRectangular(t *testing.T) {
+	// X = 1 + τ, Y = .5 cos(1 + 2τ), Z = .1
+	f := vsopFile('1', "EMB    ",
+		vsopSeries{'1', '0', [][3]float64{{1, 0, 0}}},
+		vsopSeries{'1', '1', [][3]float64{{1, 0, 0}}},
+		vsopSeries{'2', '0', [][3]float64{{.5, 1, 2}}},
+		vsopSeries{'3', '0', [][3]float64{{.1, 0, 0}}})
+	b, err := pp.LoadVariantReader(pp.VSOP87A, pp.EMB, strings.NewReader(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := b.Position(base.J2000)
+	if p.Frame != pp.HeliocentricJ2000 {
+		t.Fatal(p.Frame)
+	}
+	if p.X != 1 || math.Abs(p.Y-.5*math.Cos(1)) > 1e-15 || p.Z != .1 {
+		t.Fatal(p.X, p.Y, p.Z)
+	}
+	const d = base.JulianYear * 1000 // days per unit of τ
+	if math.Abs(p.VX-1/d) > 1e-18 ||
+		math.Abs(p.VY+math.Sin(1)/d) > 1e-18 || p.VZ != 0 {
+		t.Fatal(p.VX, p.VY, p.VZ)
+	}
+	if math.Abs(p.R-math.Sqrt(p.X*p.X+p.Y*p.Y+p.Z*p.Z)) > 1e-15 {
+		t.Fatal(p.R)
+	}
+}
+
+func TestLoadVariantSpherical(t *testing.T) {
+	f := vsopB('4', "MARS   ", 5, 1, .1, 1.5)
+	b, err := pp.LoadVariantReader(pp.VSOP87D, pp.Mars, strings.NewReader(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// velocity should match a numerical derivative of position.
+	jde := base.J2000 + 1000
+	p := b.Position(jde)
+	if p.Frame != pp.HeliocentricOfDate {
+		t.Fatal(p.Frame)
+	}
+	p1 := b.Position(jde - .5)
+	p2 := b.Position(jde + .5)
+	for _, v := range [][2]float64{
+		{p.VX, p2.X - p1.X},
+		{p.VY, p2.Y - p1.Y},
+		{p.VZ, p2.Z - p1.Z},
+	} {
+		if math.Abs(v[0]-v[1]) > 1e-12 {
+			t.Fatal(v)
+		}
+	}
+}
+
+func TestLoadVariantBodies(t *testing.T) {
+	fsys := fstest.MapFS{}
+	for _, tc := range []struct {
+		v     pp.Variant
+		ibody int
+	}{
+		{pp.VSOP87B, pp.EMB},
+		{pp.VSOP87C, pp.Sun},
+		{pp.VSOP87E, pp.EMB},
+		{'F', pp.Earth},
+	} {
+		_, err := pp.LoadVariantFS(tc.v, tc.ibody, fsys)
+		if err == nil || strings.Contains(err.Error(), "not exist") {
+			t.Errorf("%s body %d: got %v", tc.v, tc.ibody, err)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	f := vsopFile('2', "EARTH  ",
+		vsopSeries{'1', '0', [][3]float64{
+			{1, 0, 0}, {.01, 1, 10}, {.001, 2, 20}, {.00001, 3, 30}}},
+		vsopSeries{'1', '1', [][3]float64{{6, 0, 0}, {.00002, 1, 5}}},
+		vsopSeries{'2', '0', [][3]float64{{.0001, 0, 1}}},
+		vsopSeries{'3', '0', [][3]float64{{1, 0, 0}, {.0002, 1, 3}}})
+	p, err := pp.LoadPlanetReader(pp.Earth, strings.NewReader(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ΔL, ΔB, ΔR := p.Error(base.J2000); ΔL != 0 || ΔB != 0 || ΔR != 0 {
+		t.Fatal("full series error:", ΔL, ΔB, ΔR)
+	}
+	tp := p.Truncate(.005)
+	// L0 retains 2 terms, L1 retains 1, B0 retains none, R0 retains 1.
+	jde := base.J2000 + 3652.5 // τ = .01
+	ΔL, ΔB, ΔR := tp.Error(jde)
+	wantL := 2*math.Sqrt(2)*.005 + 2*.005*.01
+	if math.Abs(ΔL.Rad()-wantL) > 1e-15 ||
+		math.Abs(ΔB.Rad()-.01) > 1e-15 || math.Abs(ΔR-.01) > 1e-15 {
+		t.Fatal(ΔL, ΔB, ΔR)
+	}
+	L, B, R := p.Position2000(jde)
+	Lt, Bt, Rt := tp.Position2000(jde)
+	if math.Abs((L-Lt).Rad()) > ΔL.Rad() ||
+		math.Abs((B-Bt).Rad()) > ΔB.Rad() || math.Abs(R-Rt) > ΔR {
+		t.Fatal("truncation error exceeds estimate")
+	}
+}
+
+func TestTruncateAccuracy(t *testing.T) {
+	f := vsopFile('2', "EARTH  ",
+		vsopSeries{'1', '0', [][3]float64{
+			{1, 0, 0}, {.01, 1, 10}, {.001, 2, 20}, {.00001, 3, 30}}},
+		vsopSeries{'2', '0', [][3]float64{{.0001, 0, 1}}},
+		vsopSeries{'3', '0', [][3]float64{{1, 0, 0}, {.0002, 1, 3}}})
+	p, err := pp.LoadPlanetReader(pp.Earth, strings.NewReader(f))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jd1 := base.J2000 - 36525
+	jd2 := base.J2000 + 36525
+	tp := p.TruncateAccuracy(.005, .001, 1e-6, jd1, jd2)
+	ΔL, ΔB, ΔR := tp.Error(jd2)
+	if ΔL > .005 || ΔB > .001 || ΔR > 1e-6 {
+		t.Fatal(ΔL, ΔB, ΔR)
+	}
+	// The .00001 term of L can go, the single term of B can go,
+	// nothing of R can go.
+	if ΔL.Rad() != 2*math.Sqrt(3)*.001 || ΔB == 0 || ΔR != 0 {
+		t.Fatal(ΔL, ΔB, ΔR)
+	}
+}
+
+func TestKeplerian(t *testing.T) {
+	// Example 32.a, p. 219, for equinox of date.
+	v, err := pp.NewKeplerian(pp.Venus)
+	if err != nil {
+		t.Fatal(err)
+	}
+	L, B, R := v.Position(julian.CalendarGregorianToJD(1992, 12, 20))
+	if math.Abs(L.Deg()-26.11412) > .01 || math.Abs(B.Deg()+2.62060) > .01 ||
+		math.Abs(R-.724602) > 1e-4 {
+		t.Error("Venus:", L.Deg(), B.Deg(), R)
+	}
+	// Mars 1899 spherical data from vsop87.chk, for J2000.
+	m, err := pp.NewKeplerian(pp.Mars)
+	if err != nil {
+		t.Fatal(err)
+	}
+	L, B, R = m.Position2000(2415020.0)
+	if math.Abs(L.Rad()-5.0185792656) > .001 ||
+		math.Abs(B.Rad()+.02740735) > .001 || math.Abs(R-1.4218777718) > .001 {
+		t.Error("Mars:", L.Rad(), B.Rad(), R)
+	}
+	if _, err := pp.NewKeplerian(pp.Sun); err == nil {
+		t.Error("expected error for invalid planet")
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package planetposition
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/soniakeys/unit"
+)
+
+// truncation records how a series was truncated.
+type truncation struct {
+	a   float64 // amplitude limit, terms smaller than a were removed
+	cut [6]bool // series from which terms were removed
+	n   [6]int  // number of terms retained in each series
+}
+
+// Truncate returns a V87Planet with all terms of amplitude less than a
+// removed.
+// 截断 VSOP87 级数，舍去振幅小于 a 的项
+//
+// Argument a is in the units of the series, that is, radians for L and B
+// and AU for R.  The receiver vt is not modified.
+//
+// Evaluation of a truncated V87Planet is faster, but less accurate.
+// Method Error gives an estimate of the error introduced.
+func (vt *V87Planet) Truncate(a float64) *V87Planet {
+	return vt.truncate(a, a, a)
+}
+
+// TruncateAccuracy returns a truncated V87Planet meeting requested
+// accuracies.
+// 按精度要求截断 VSOP87 级数
+//
+// Arguments ΔL, ΔB, and ΔR are the largest acceptable errors in longitude,
+// latitude, and range.  Arguments jde1 and jde2 give the range of dates
+// over which the accuracy is needed.
+//
+// For each of L, B, and R, the largest amplitude limit is chosen for which
+// the estimate of method Error does not exceed the requested accuracy
+// anywhere in the range of dates.
+func (vt *V87Planet) TruncateAccuracy(ΔL, ΔB unit.Angle, ΔR, jde1, jde2 float64) *V87Planet {
+	τ := math.Max(math.Abs(base.J2000Century(jde1)),
+		math.Abs(base.J2000Century(jde2))) * .1
+	return vt.truncate(
+		vt.l.limit(ΔL.Rad(), τ),
+		vt.b.limit(ΔB.Rad(), τ),
+		vt.r.limit(ΔR, τ))
+}
+
+// Error returns estimated errors of positions computed from a truncated
+// V87Planet.
+// 截断级数的误差估计
+//
+// The estimate follows chapter 32: when only terms of amplitude at least A
+// are retained in a series, the error of the series is about 2√n A, where
+// n is the number of terms retained.  For series in higher powers of τ the
+// estimate is multiplied by the same power of τ.
+//
+// Only the error due to truncation is estimated.  Results are zero for
+// full VSOP87 series.
+func (vt *V87Planet) Error(jde float64) (ΔL, ΔB unit.Angle, ΔR float64) {
+	τ := math.Abs(base.J2000Century(jde) * .1)
+	return unit.Angle(vt.tl.error(τ)), unit.Angle(vt.tb.error(τ)),
+		vt.tr.error(τ)
+}
+
+func (vt *V87Planet) truncate(aL, aB, aR float64) *V87Planet {
+	t := &V87Planet{}
+	t.l, t.tl = vt.l.truncate(aL, vt.tl)
+	t.b, t.tb = vt.b.truncate(aB, vt.tb)
+	t.r, t.tr = vt.r.truncate(aR, vt.tr)
+	return t
+}
+
+// truncate returns a copy of c without terms smaller than a.
+//
+// Argument tr describes any previous truncation of c.
+func (c *coeff) truncate(a float64, tr truncation) (t coeff, tt truncation) {
+	tt = tr
+	if a > tt.a {
+		tt.a = a
+	}
+	for x, terms := range c {
+		for _, term := range terms {
+			if math.Abs(term.a) >= a {
+				t[x] = append(t[x], term)
+			}
+		}
+		if len(t[x]) < len(terms) {
+			tt.cut[x] = true
+		}
+		tt.n[x] = len(t[x])
+	}
+	return
+}
+
+// error returns the truncation error estimate at |τ|.
+func (tr *truncation) error(τ float64) (e float64) {
+	τα := 1.
+	for x, cut := range tr.cut {
+		if cut {
+			e += truncError(tr.n[x], tr.a) * τα
+		}
+		τα *= τ
+	}
+	return
+}
+
+// truncError returns the chapter 32 error estimate for a series
+// retaining n terms of amplitude a or more.
+func truncError(n int, a float64) float64 {
+	if n < 1 {
+		// all terms removed.  the largest was still less than a.
+		n = 1
+	}
+	return 2 * math.Sqrt(float64(n)) * a
+}
+
+// limit returns the largest amplitude limit for c that keeps the
+// error estimate within Δ at |τ|.
+func (c *coeff) limit(Δ, τ float64) float64 {
+	type amp struct {
+		a float64
+		x int
+	}
+	var amps []amp
+	var n [6]int
+	for x, terms := range c {
+		for _, term := range terms {
+			amps = append(amps, amp{math.Abs(term.a), x})
+		}
+		n[x] = len(terms)
+	}
+	if len(amps) == 0 {
+		return 0
+	}
+	sort.Slice(amps, func(i, j int) bool { return amps[i].a < amps[j].a })
+	// Try each amplitude in increasing order as the limit.  Terms smaller
+	// than the limit are removed as the limit increases.  A final limit
+	// just above the largest amplitude removes all terms.
+	var cut [6]bool
+	best := 0.
+	for i := 0; i <= len(amps); {
+		var a float64
+		if i < len(amps) {
+			a = amps[i].a
+		} else {
+			a = math.Nextafter(amps[i-1].a, math.Inf(1))
+		}
+		e := 0.
+		τα := 1.
+		for x := range c {
+			if cut[x] {
+				e += truncError(n[x], a) * τα
+			}
+			τα *= τ
+		}
+		if e > Δ {
+			break
+		}
+		best = a
+		if i == len(amps) {
+			break
+		}
+		// remove all terms of amplitude a
+		for ; i < len(amps) && amps[i].a == a; i++ {
+			n[amps[i].x]--
+			cut[amps[i].x] = true
+		}
+	}
+	return best
+}
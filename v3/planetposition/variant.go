@@ -0,0 +1,280 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package planetposition
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/soniakeys/unit"
+)
+
+// Variant identifies one of the VSOP87 solutions.
+//
+// VSOP87B is the variant used by V87Planet.  The other variants can be
+// loaded with LoadVariant and related functions.
+type Variant byte
+
+// VSOP87 variants, named by the letter used in the VSOP87 file names.
+const (
+	VSOP87A Variant = 'A' // heliocentric rectangular, J2000
+	VSOP87B Variant = 'B' // heliocentric spherical, J2000
+	VSOP87C Variant = 'C' // heliocentric rectangular, of date
+	VSOP87D Variant = 'D' // heliocentric spherical, of date
+	VSOP87E Variant = 'E' // barycentric rectangular, J2000
+)
+
+// String returns the VSOP87 name of the variant, "VSOP87A" for example.
+func (v Variant) String() string {
+	return "VSOP87" + string(v)
+}
+
+// version returns the version digit found in files of the variant.
+func (v Variant) version() byte {
+	return byte(v) - 'A' + '1'
+}
+
+// Spherical returns true if the variant is expressed as spherical
+// coordinates L, B, R rather than rectangular coordinates X, Y, Z.
+func (v Variant) Spherical() bool {
+	return v == VSOP87B || v == VSOP87D
+}
+
+// Frame returns the reference frame of the variant.
+func (v Variant) Frame() Frame {
+	switch v {
+	case VSOP87C, VSOP87D:
+		return HeliocentricOfDate
+	case VSOP87E:
+		return BarycentricJ2000
+	}
+	return HeliocentricJ2000
+}
+
+// hasBody returns true if the variant provides series for body ibody.
+func (v Variant) hasBody(ibody int) bool {
+	switch {
+	case ibody >= 0 && ibody < nPlanets:
+		return true
+	case ibody == EMB:
+		return v == VSOP87A
+	case ibody == Sun:
+		return v == VSOP87E
+	}
+	return false
+}
+
+// Frame identifies the reference frame of a VSOP87 position.
+type Frame int
+
+// Reference frames of the VSOP87 variants.  All are ecliptic frames using
+// the dynamical ecliptic and equinox.
+const (
+	HeliocentricJ2000  Frame = iota // heliocentric, ecliptic and equinox J2000
+	HeliocentricOfDate              // heliocentric, ecliptic and equinox of date
+	BarycentricJ2000                // barycentric, ecliptic and equinox J2000
+)
+
+var frameNames = [...]string{
+	"heliocentric J2000",
+	"heliocentric of date",
+	"barycentric J2000",
+}
+
+// String returns a description of the frame, "heliocentric J2000" for example.
+func (f Frame) String() string {
+	if f < 0 || int(f) >= len(frameNames) {
+		return fmt.Sprintf("Frame(%d)", int(f))
+	}
+	return frameNames[f]
+}
+
+// V87Body holds VSOP87 coefficients of any variant for a single body.
+//
+// Obtain V87Body objects with LoadVariant and related functions.
+type V87Body struct {
+	variant Variant
+	ibody   int
+	v       [3]coeff // L, B, R or X, Y, Z
+}
+
+// V87Position holds position and velocity computed from a V87Body.
+//
+// Both spherical and rectangular forms of position are given, regardless
+// of the form of the variant.  Velocity is given in rectangular form.
+type V87Position struct {
+	Frame Frame // reference frame of all coordinates
+
+	L unit.Angle // longitude
+	B unit.Angle // latitude
+	R float64    // range in AU
+
+	X, Y, Z    float64 // rectangular coordinates in AU
+	VX, VY, VZ float64 // velocity in AU/day
+}
+
+// LoadVariant constructs a V87Body object from a VSOP87 file of the given
+// variant.
+// 读取任意版本的 VSOP87 文件
+//
+// Argument ibody should be one of the planet or body constants.
+//
+// The directory containing the VSOP87 files must be indicated by
+// environment variable VSOP87.
+func LoadVariant(v Variant, ibody int) (*V87Body, error) {
+	path := os.Getenv("VSOP87")
+	if path == "" {
+		return nil, errors.New("No path assigned to environment variable VSOP87")
+	}
+	return LoadVariantPath(v, ibody, path)
+}
+
+// LoadVariantPath constructs a V87Body object from a VSOP87 file of the
+// given variant.
+//
+// Argument ibody should be one of the planet or body constants; path should
+// be a directory containing the VSOP87 files.
+func LoadVariantPath(v Variant, ibody int, path string) (*V87Body, error) {
+	name, err := variantFile(v, ibody)
+	if err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(filepath.Join(path, name))
+	if err != nil {
+		return nil, err
+	}
+	return loadVariantData(v, ibody, data)
+}
+
+// LoadVariantFS constructs a V87Body object from a VSOP87 file in a file
+// system.
+//
+// Argument ibody should be one of the planet or body constants; fsys should
+// hold the VSOP87 files at its root under their original names, as
+// described for LoadPlanetFS.
+func LoadVariantFS(v Variant, ibody int, fsys fs.FS) (*V87Body, error) {
+	name, err := variantFile(v, ibody)
+	if err != nil {
+		return nil, err
+	}
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return loadVariantData(v, ibody, data)
+}
+
+// LoadVariantReader constructs a V87Body object from VSOP87 data read
+// from r.
+//
+// Argument ibody should be one of the planet or body constants.  The data
+// read must be the contents of the VSOP87 file of the given variant for
+// that body.
+func LoadVariantReader(v Variant, ibody int, r io.Reader) (*V87Body, error) {
+	if _, err := variantFile(v, ibody); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return loadVariantData(v, ibody, data)
+}
+
+// variantFile validates v and ibody and returns the VSOP87 file name.
+func variantFile(v Variant, ibody int) (string, error) {
+	if v < VSOP87A || v > VSOP87E {
+		return "", errors.New("Invalid VSOP87 variant.")
+	}
+	if !v.hasBody(ibody) {
+		return "", fmt.Errorf("Invalid body for %s.", v)
+	}
+	return v.String() + "." + ext[ibody], nil
+}
+
+func loadVariantData(v Variant, ibody int, data []byte) (*V87Body, error) {
+	b := &V87Body{variant: v, ibody: ibody}
+	lines := strings.Split(string(data), "\n")
+	n := 0
+	var err error
+	for i := range b.v {
+		n, err = b.v[i].parse(byte('1'+i), v.version(), ibody, lines, n,
+			i == 2)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Variant returns the VSOP87 variant of the coefficients held by b.
+func (b *V87Body) Variant() Variant { return b.variant }
+
+// Position returns position and velocity of the body by full VSOP87 theory.
+// 计算天体的位置和速度
+//
+// Argument jde is the date for which position is desired.
+//
+// Results are in the frame of the variant, as indicated by the Frame
+// field of the result.
+func (b *V87Body) Position(jde float64) *V87Position {
+	τ := base.J2000Century(jde) * .1
+	var v, dv [3]float64
+	for i := range b.v {
+		v[i], dv[i] = b.v[i].eval(τ)
+		// rates from per millennium to per day
+		dv[i] /= base.JulianYear * 1000
+	}
+	p := &V87Position{Frame: b.variant.Frame()}
+	if !b.variant.Spherical() {
+		p.X, p.Y, p.Z = v[0], v[1], v[2]
+		p.VX, p.VY, p.VZ = dv[0], dv[1], dv[2]
+		p.L = unit.Angle(unit.PMod(math.Atan2(p.Y, p.X), 2*math.Pi))
+		p.B = unit.Angle(math.Atan2(p.Z, math.Hypot(p.X, p.Y)))
+		p.R = math.Sqrt(p.X*p.X + p.Y*p.Y + p.Z*p.Z)
+		return p
+	}
+	p.L = unit.Angle(unit.PMod(v[0], 2*math.Pi))
+	p.B = unit.Angle(v[1])
+	p.R = v[2]
+	sL, cL := p.L.Sincos()
+	sB, cB := p.B.Sincos()
+	p.X = p.R * cB * cL
+	p.Y = p.R * cB * sL
+	p.Z = p.R * sB
+	// derivatives of the above by the chain rule
+	dL, dB, dR := dv[0], dv[1], dv[2]
+	p.VX = dR*cB*cL - p.R*sB*cL*dB - p.R*cB*sL*dL
+	p.VY = dR*cB*sL - p.R*sB*sL*dB + p.R*cB*cL*dL
+	p.VZ = dR*sB + p.R*cB*dB
+	return p
+}
+
+// eval evaluates a series and its derivative with respect to τ.
+func (c *coeff) eval(τ float64) (v, dv float64) {
+	// powers of τ, highest first, so each step is one term of Horner's
+	// method applied to both the value and its derivative.
+	for x := len(c) - 1; x >= 0; x-- {
+		var s, ds float64
+		terms := c[x]
+		// sum terms in reverse order to preserve accuracy
+		for y := len(terms) - 1; y >= 0; y-- {
+			term := &terms[y]
+			sa, ca := math.Sincos(term.b + term.c*τ)
+			s += term.a * ca
+			ds -= term.a * term.c * sa
+		}
+		dv = dv*τ + v + ds
+		v = v*τ + s
+	}
+	return
+}
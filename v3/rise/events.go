@@ -0,0 +1,123 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package rise
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mooncaker816/learnmeeus/v3/body"
+	"github.com/mooncaker816/learnmeeus/v3/coord"
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	"github.com/mooncaker816/learnmeeus/v3/globe"
+	"github.com/mooncaker816/learnmeeus/v3/iterate"
+	"github.com/mooncaker816/learnmeeus/v3/sidereal"
+	"github.com/soniakeys/unit"
+)
+
+// EventKind identifies the kind of an Event.
+type EventKind int
+
+// Kinds of events found by Events.
+const (
+	Rising       EventKind = iota // body rises through the standard altitude
+	Transit                       // body crosses the meridian above the pole
+	LowerTransit                  // body crosses the meridian below the pole
+	Setting                       // body sets through the standard altitude
+)
+
+var eventNames = [...]string{"rising", "transit", "lower transit", "setting"}
+
+// String returns the name of the event kind, "rising" for example.
+func (k EventKind) String() string {
+	if k < 0 || int(k) >= len(eventNames) {
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+	return eventNames[k]
+}
+
+// Event is a rising, transit, lower transit, or setting of a body.
+type Event struct {
+	Kind EventKind
+	JD   float64 // time of the event, as a UT julian day
+}
+
+// scanStep is the interval in days at which Events samples the position
+// of a body.
+const scanStep = 1. / 48
+
+// Events returns all risings, transits, lower transits, and settings of a
+// body between two times.
+// 计算时间段内天体所有的升，中天，下中天，降事件
+//
+//	b is the body.
+//	pos is geographic coordinates of observer.
+//	h0 is "standard altitude" of the body.
+//	jd1, jd2 are the UT julian days bounding the interval of interest.
+//
+// Unlike Times, which finds a single rise, transit, and set for a day,
+// Events finds any number of each, so it handles days with no rising of
+// the Moon, or two, and days at high latitudes where a body does not set.
+//
+// Events are returned in order of time.  Result up is true if the body is
+// above h0 at jd1.  When no Rising or Setting events are returned, up then
+// tells whether the body is up for the whole interval or down for the
+// whole interval.
+//
+// Altitude and hour angle are sampled every half hour and each sign change
+// is refined by binary search.  A rising and setting less than half an hour
+// apart, as when a body barely grazes the horizon, may be missed.
+func Events(b body.Body, pos globe.Coord, h0 unit.Angle, jd1, jd2 float64) (events []Event, up bool) {
+	ΔT := deltat.Interp10A(jd1).Day()
+	// altitude less h0, and sine and cosine of hour angle
+	f := func(jd float64) (h, sH, cH float64) {
+		α, δ, _ := b.Apparent(jd + ΔT)
+		st := sidereal.Apparent(jd)
+		_, hz := coord.EqToHz(α, δ, pos.Lat, pos.Lon, st)
+		sH, cH = math.Sincos(st.Rad() - pos.Lon.Rad() - α.Rad())
+		return (hz - h0).Rad(), sH, cH
+	}
+	alt := func(jd float64) float64 {
+		h, _, _ := f(jd)
+		return h
+	}
+	sinH := func(jd float64) float64 {
+		_, sH, _ := f(jd)
+		return sH
+	}
+	n := int(math.Ceil((jd2 - jd1) / scanStep))
+	if n < 1 {
+		n = 1
+	}
+	step := (jd2 - jd1) / float64(n)
+	t0 := jd1
+	a0, s0, c0 := f(t0)
+	up = a0 > 0
+	for i := 1; i <= n; i++ {
+		t1 := jd1 + float64(i)*step
+		a1, s1, c1 := f(t1)
+		if (a0 > 0) != (a1 > 0) {
+			k := Rising
+			if a0 > 0 {
+				k = Setting
+			}
+			events = append(events, Event{k, iterate.BinaryRoot(alt, t0, t1)})
+		}
+		// hour angle increases through 0 at transit, through π at lower
+		// transit.  sin H changes sign at both.
+		if (s0 > 0) != (s1 > 0) {
+			k := Transit
+			if c0+c1 < 0 {
+				k = LowerTransit
+			}
+			events = append(events, Event{k, iterate.BinaryRoot(sinH, t0, t1)})
+		}
+		// a step can hold a crossing of both kinds
+		if l := len(events); l > 1 && events[l-1].JD < events[l-2].JD {
+			events[l-1], events[l-2] = events[l-2], events[l-1]
+		}
+		t0, a0, s0, c0 = t1, a1, s1, c1
+	}
+	return
+}
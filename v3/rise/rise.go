@@ -9,20 +9,27 @@
 // The function signatures aren't very friendly though, requiring a number of
 // precomputed values.  The example worked in the text gives these values for
 // the planet Venus.  With these example values as test data, methods
-// ApproxPlanet and Planet are also given here.  Similar methods for stars,
-// the Sun, Moon, Pluto, or asteroids might also be developed using other
-// packages from this library.
+// ApproxPlanet and Planet are also given here.
+//
+// Functions Sun, Moon, Star, and Elements give times for other kinds of
+// objects, and function Body gives times for any body.Body, such as Pluto
+// or a comet.  Function TwilightTimes gives times of civil, nautical, and
+// astronomical twilight.
 package rise
 
 import (
 	"errors"
+	"fmt"
 	"math"
 
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/body"
 	"github.com/mooncaker816/learnmeeus/v3/deltat"
 	"github.com/mooncaker816/learnmeeus/v3/elliptic"
 	"github.com/mooncaker816/learnmeeus/v3/globe"
 	"github.com/mooncaker816/learnmeeus/v3/interp"
 	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/mooncaker816/learnmeeus/v3/moonposition"
 	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
 	"github.com/mooncaker816/learnmeeus/v3/sidereal"
 	"github.com/soniakeys/unit"
@@ -104,18 +111,27 @@ func ApproxTimes(p globe.Coord, h0 unit.Angle, Th0 unit.Time, α unit.RA, δ uni
 // See sidereal.Apparent0UT.
 //
 // α3, δ3 must be values at 0h dynamical time for the day before, the day of,
-// and the day after the day of interest.  Units are radians.
+// and the day after the day of interest.  Units are radians.  The right
+// ascensions may pass through 0ʰ.
 //
 // Result units are seconds of day and are in the range [0,86400).
 // 对近似计算结果迭代，得到精确升，中天，降时间
 func Times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []unit.RA, δ3 []unit.Angle) (tRise, tTransit, tSet unit.Time, err error) {
+	return times(p, ΔT, h0, Th0, α3, δ3, 1)
+}
+
+// times implements Times, applying the corrections of p. 103 up to n times.
+//
+// Corrections stop early once they are smaller than a second.
+func times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []unit.RA, δ3 []unit.Angle, n int) (tRise, tTransit, tSet unit.Time, err error) {
 	tRise, tTransit, tSet, err = ApproxTimes(p, h0, Th0, α3[1], δ3[1])
 	if err != nil {
 		return
 	}
 	αf := make([]float64, 3)
 	for i, α := range α3 {
-		αf[i] = α.Rad()
+		// keep α continuous where it passes through 0ʰ
+		αf[i] = α3[1].Rad() + math.Remainder((α-α3[1]).Rad(), 2*math.Pi)
 	}
 	δf := make([]float64, 3)
 	for i, δ := range δ3 {
@@ -130,17 +146,17 @@ func Times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []uni
 	if err != nil {
 		return
 	}
-	// adjust tTransit
-	{
-		th0 := (Th0 + tTransit.Mul(360.985647/360)).Mod1()
-		α := d3α.InterpolateX((tTransit + ΔT).Sec())
+	// correction to tTransit
+	adjustT := func(m unit.Time) unit.Time {
+		th0 := (Th0 + m.Mul(360.985647/360)).Mod1()
+		α := d3α.InterpolateX((m + ΔT).Sec())
 		// local hour angle as Time
 		H := th0 - unit.TimeFromRad(p.Lon.Rad()+α)
-		tTransit -= H
+		return -unit.Time(math.Remainder(H.Sec(), 86400))
 	}
-	// adjust tRise, tSet
+	// correction to tRise, tSet
 	sLat, cLat := p.Lat.Sincos()
-	adjustRS := func(m unit.Time) (unit.Time, error) {
+	adjustRS := func(m unit.Time) unit.Time {
 		th0 := (Th0 + m.Mul(360.985647/360)).Mod1()
 		ut := (m + ΔT).Sec()
 		α := d3α.InterpolateX(ut)
@@ -149,14 +165,20 @@ func Times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []uni
 		sδ, cδ := math.Sincos(δ)
 		sH, cH := math.Sincos(Hrad)
 		h := math.Asin(sLat*sδ + cLat*cδ*cH)
-		md := (unit.TimeFromRad(h) - h0.Time()).Div(cδ * cLat * sH)
-		return m + md, nil
+		return (unit.TimeFromRad(h) - h0.Time()).Div(cδ * cLat * sH)
 	}
-	tRise, err = adjustRS(tRise)
-	if err != nil {
-		return
+	for i := 0; i < n; i++ {
+		dt := adjustT(tTransit)
+		dr := adjustRS(tRise)
+		ds := adjustRS(tSet)
+		tTransit += dt
+		tRise += dr
+		tSet += ds
+		if math.Abs(dt.Sec()) < 1 && math.Abs(dr.Sec()) < 1 &&
+			math.Abs(ds.Sec()) < 1 {
+			break
+		}
 	}
-	tSet, err = adjustRS(tSet)
 	return
 }
 
@@ -165,13 +187,15 @@ func Times(p globe.Coord, ΔT unit.Time, h0 unit.Angle, Th0 unit.Time, α3 []uni
 //
 //  yr, mon, day are the Gregorian date.
 //  pos is geographic coordinates of observer.
-//  e must be a V87Planet object for Earth
-//  pl must be a V87Planet object for another planet.
+//  e must be a Planet object for Earth
+//  pl must be a Planet object for another planet.
 //
-// Obtain V87Planet objects with the planetposition package.
+// Obtain Planet objects, such as V87Planet or Keplerian, with the
+// planetposition package.  Keplerian neglects perturbations, and for
+// Jupiter and Saturn limits results to a few minutes of time.
 //
 // Result units are seconds of day and are in the range [0,86400).
-func ApproxPlanet(yr, mon, day int, pos globe.Coord, e, pl *pp.V87Planet) (tRise, tTransit, tSet unit.Time, err error) {
+func ApproxPlanet(yr, mon, day int, pos globe.Coord, e, pl pp.Planet) (tRise, tTransit, tSet unit.Time, err error) {
 	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
 	α, δ := elliptic.Position(pl, e, jd)
 	return ApproxTimes(pos, Stdh0Stellar, sidereal.Apparent0UT(jd), α, δ)
@@ -182,13 +206,15 @@ func ApproxPlanet(yr, mon, day int, pos globe.Coord, e, pl *pp.V87Planet) (tRise
 //
 //  yr, mon, day are the Gregorian date.
 //  pos is geographic coordinates of observer.
-//  e must be a V87Planet object for Earth
-//  pl must be a V87Planet object for another planet.
+//  e must be a Planet object for Earth
+//  pl must be a Planet object for another planet.
 //
-// Obtain V87Planet objects with the planetposition package.
+// Obtain Planet objects, such as V87Planet or Keplerian, with the
+// planetposition package.  Keplerian neglects perturbations, and for
+// Jupiter and Saturn limits results to a few minutes of time.
 //
 // Result units are seconds of day and are in the range [0,86400).
-func Planet(yr, mon, day int, pos globe.Coord, e, pl *pp.V87Planet) (tRise, tTransit, tSet unit.Time, err error) {
+func Planet(yr, mon, day int, pos globe.Coord, e, pl pp.Planet) (tRise, tTransit, tSet unit.Time, err error) {
 	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
 	α := make([]unit.RA, 3)
 	δ := make([]unit.Angle, 3)
@@ -198,3 +224,166 @@ func Planet(yr, mon, day int, pos globe.Coord, e, pl *pp.V87Planet) (tRise, tTra
 	return Times(pos, deltat.Interp10A(jd), Stdh0Stellar,
 		sidereal.Apparent0UT(jd), α, δ)
 }
+
+// Sun computes UT rise, transit and set times for the Sun on a day of
+// interest.
+// 太阳的升，中天，降时间
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	e must be a Planet object for Earth.
+//
+// Rise and set are for the standard altitude Stdh0Solar.
+//
+// Result units are seconds of day.
+func Sun(yr, mon, day int, pos globe.Coord, e pp.Planet) (tRise, tTransit, tSet unit.Time, err error) {
+	return Body(yr, mon, day, pos, body.Sun{Earth: e}, Stdh0Solar)
+}
+
+// Moon computes UT rise, transit and set times for the Moon on a day of
+// interest.
+// 月亮的升，中天，降时间
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//
+// Rise and set are for the standard altitude Stdh0Lunar, using the
+// horizontal parallax of the Moon at 0h of the day.
+//
+// Result units are seconds of day.
+func Moon(yr, mon, day int, pos globe.Coord) (tRise, tTransit, tSet unit.Time, err error) {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	_, _, Δ := body.Moon{}.Apparent(jd)
+	h0 := Stdh0Lunar(moonposition.Parallax(Δ * base.AU))
+	return Body(yr, mon, day, pos, body.Moon{}, h0)
+}
+
+// Star computes UT rise, transit and set times for a star on a day of
+// interest.
+// 恒星的升，中天，降时间
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	s is the catalog position and proper motion of the star.
+//
+// Rise and set are for the standard altitude Stdh0Stellar.
+//
+// Result units are seconds of day.
+func Star(yr, mon, day int, pos globe.Coord, s body.Star) (tRise, tTransit, tSet unit.Time, err error) {
+	return Body(yr, mon, day, pos, s, Stdh0Stellar)
+}
+
+// Elements computes UT rise, transit and set times for a body with
+// Keplerian elements on a day of interest.
+// 椭圆轨道天体的升，中天，降时间
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	e must be a Planet object for Earth.
+//	k are the elements, referenced to the ecliptic and equinox of J2000.
+//
+// Rise and set are for the standard altitude Stdh0Stellar.
+//
+// Result units are seconds of day.
+func Elements(yr, mon, day int, pos globe.Coord, e pp.Planet, k *elliptic.Elements) (tRise, tTransit, tSet unit.Time, err error) {
+	return Body(yr, mon, day, pos, body.NewElliptic(k, e), Stdh0Stellar)
+}
+
+// Body computes UT rise, transit and set times for any body on a day of
+// interest.
+// 任意天体的升，中天，降时间
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	b is the body.
+//	h0 is "standard altitude" of the body.
+//
+// Apparent positions are computed for the day before, the day of, and the
+// day after the day of interest, and the corrections of Times are repeated
+// until they are smaller than a second.  This gives good results even for
+// the quickly moving Moon.
+//
+// Result units are seconds of day.  After correction, times may fall
+// slightly outside of the range [0,86400).
+func Body(yr, mon, day int, pos globe.Coord, b body.Body, h0 unit.Angle) (tRise, tTransit, tSet unit.Time, err error) {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	α, δ := ephemeris3(b, jd)
+	return times(pos, deltat.Interp10A(jd), h0, sidereal.Apparent0UT(jd),
+		α, δ, 10)
+}
+
+// ephemeris3 returns apparent positions of b for the day before, the day
+// of, and the day after jd.
+func ephemeris3(b body.Body, jd float64) (α []unit.RA, δ []unit.Angle) {
+	α = make([]unit.RA, 3)
+	δ = make([]unit.Angle, 3)
+	for i := range α {
+		α[i], δ[i], _ = b.Apparent(jd + float64(i-1))
+	}
+	return
+}
+
+// Twilight identifies a kind of twilight by the depression of the Sun
+// below the horizon.
+type Twilight int
+
+// Kinds of twilight.
+const (
+	Civil        Twilight = iota // Sun 6° below the horizon
+	Nautical                     // Sun 12° below the horizon
+	Astronomical                 // Sun 18° below the horizon
+)
+
+var twilightNames = [...]string{"civil", "nautical", "astronomical"}
+
+// String returns the name of the twilight kind, "civil" for example.
+func (tw Twilight) String() string {
+	if tw < 0 || int(tw) >= len(twilightNames) {
+		return fmt.Sprintf("Twilight(%d)", int(tw))
+	}
+	return twilightNames[tw]
+}
+
+// H0 returns the altitude of the center of the Sun at the beginning of
+// morning twilight and the end of evening twilight.
+func (tw Twilight) H0() unit.Angle {
+	return unit.AngleFromDeg(-6 * float64(tw+1))
+}
+
+// ErrorNoNight is returned by TwilightTimes when the Sun does not descend
+// as far as the depression of the twilight on the day of interest, as
+// happens near midsummer at high latitudes.
+var ErrorNoNight = errors.New("No night")
+
+// TwilightTimes computes UT times of the beginning of morning twilight
+// and the end of evening twilight on a day of interest.
+// 计算晨昏蒙影的开始和结束时间
+//
+//	yr, mon, day are the Gregorian date.
+//	pos is geographic coordinates of observer.
+//	e must be a Planet object for Earth.
+//	tw is the kind of twilight.
+//
+// Dawn is the time the Sun rises through altitude tw.H0(), dusk the time
+// it sets through that altitude.
+//
+// ErrorNoNight is returned if the Sun stays above tw.H0() all day.
+// ErrorCircumpolar is returned if the Sun stays below tw.H0() all day.
+//
+// Result units are seconds of day.
+func TwilightTimes(yr, mon, day int, pos globe.Coord, e pp.Planet, tw Twilight) (tDawn, tDusk unit.Time, err error) {
+	jd := julian.CalendarGregorianToJD(yr, mon, float64(day))
+	α, δ := ephemeris3(body.Sun{Earth: e}, jd)
+	h0 := tw.H0()
+	tDawn, _, tDusk, err = times(pos, deltat.Interp10A(jd), h0,
+		sidereal.Apparent0UT(jd), α, δ, 10)
+	if err == ErrorCircumpolar {
+		// altitude at lower transit
+		sLat, cLat := pos.Lat.Sincos()
+		sδ, cδ := δ[1].Sincos()
+		if math.Asin(sLat*sδ-cLat*cδ) > h0.Rad() {
+			err = ErrorNoNight
+		}
+	}
+	return
+}
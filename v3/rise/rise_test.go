@@ -5,9 +5,18 @@ package rise_test
 
 import (
 	"fmt"
+	"math"
+	"testing"
 
+	"github.com/mooncaker816/learnmeeus/v3/body"
+	"github.com/mooncaker816/learnmeeus/v3/coord"
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	"github.com/mooncaker816/learnmeeus/v3/elliptic"
 	"github.com/mooncaker816/learnmeeus/v3/globe"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
 	"github.com/mooncaker816/learnmeeus/v3/rise"
+	"github.com/mooncaker816/learnmeeus/v3/sidereal"
 	"github.com/soniakeys/sexagesimal"
 	"github.com/soniakeys/unit"
 )
@@ -71,3 +80,311 @@ func ExampleTimes() {
 	// transit: +0.81980  19ʰ40ᵐ30ˢ
 	// seting:  +0.12130  02ʰ54ᵐ40ˢ
 }
+
+// TestTimesRAWrap checks that Times handles right ascensions passing
+// through 0ʰ, as those of the Sun near the March equinox.
+func TestTimesRAWrap(t *testing.T) {
+	p := globe.Coord{
+		Lon: unit.AngleFromDeg(-30),
+		Lat: unit.AngleFromDeg(40),
+	}
+	Th0 := unit.NewTime(' ', 12, 0, 0)
+	α3 := []unit.RA{
+		unit.NewRA(23, 56, 0),
+		unit.NewRA(0, 0, 0),
+		unit.NewRA(0, 4, 0),
+	}
+	δ3 := []unit.Angle{
+		unit.AngleFromDeg(-.4),
+		0,
+		unit.AngleFromDeg(.4),
+	}
+	h0 := rise.Stdh0Solar
+	aRise, aTransit, aSet, err := rise.ApproxTimes(p, h0, Th0, α3[1], δ3[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	tRise, tTransit, tSet, err := rise.Times(p, 0, h0, Th0, α3, δ3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// corrections are within the motion of the body over the day
+	for _, c := range []struct {
+		name string
+		a, t unit.Time
+	}{
+		{"rise", aRise, tRise},
+		{"transit", aTransit, tTransit},
+		{"set", aSet, tSet},
+	} {
+		if math.Abs((c.t - c.a).Sec()) > 300 {
+			t.Errorf("%s %v, approximate %v",
+				c.name, sexa.FmtTime(c.t), sexa.FmtTime(c.a))
+		}
+	}
+}
+
+func ExamplePlanet_keplerian() {
+	// Example 15.a, p. 103, using the built in Keplerian theory so that no
+	// VSOP87 files are needed.  Results agree with those of full VSOP87 to
+	// within a minute.
+	p := globe.Coord{
+		Lon: unit.NewAngle(' ', 71, 5, 0),
+		Lat: unit.NewAngle(' ', 42, 20, 0),
+	}
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	v, err := pp.NewKeplerian(pp.Venus)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	tRise, tTransit, tSet, err := rise.Planet(1988, 3, 20, p, e, v)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("rising:  %02m\n", sexa.FmtTime(tRise))
+	fmt.Printf("transit: %02m\n", sexa.FmtTime(tTransit))
+	fmt.Printf("seting:  %02m\n", sexa.FmtTime(tSet))
+	// Output:
+	// rising:   12ʰ25ᵐ
+	// transit:  19ʰ41ᵐ
+	// seting:   02ʰ55ᵐ
+}
+
+// boston is the observer of Example 15.a.
+var boston = globe.Coord{
+	Lon: unit.NewAngle(' ', 71, 5, 0),
+	Lat: unit.NewAngle(' ', 42, 20, 0),
+}
+
+// altitude returns altitude and hour angle of b at UT time t on the day of
+// jd0.
+func altitude(b body.Body, p globe.Coord, jd0 float64, t unit.Time) (h unit.Angle, H unit.HourAngle) {
+	jd := jd0 + t.Day()
+	α, δ, _ := b.Apparent(jd + deltat.Interp10A(jd0).Day())
+	st := sidereal.Apparent(jd)
+	_, h = coord.EqToHz(α, δ, p.Lat, p.Lon, st)
+	H = unit.HourAngle(math.Remainder(st.Rad()-p.Lon.Rad()-α.Rad(), 2*math.Pi))
+	return
+}
+
+// checkTimes verifies that b is at altitude h0 at tRise and tSet, rising
+// and setting, and at hour angle 0 at tTransit.
+func checkTimes(t *testing.T, b body.Body, jd0 float64, h0 unit.Angle, tRise, tTransit, tSet unit.Time, tol unit.Angle) {
+	h, H := altitude(b, boston, jd0, tRise)
+	if math.Abs((h-h0).Deg()) > tol.Deg() || H > 0 {
+		t.Error("rise:", h.Deg(), H.Hour())
+	}
+	h, H = altitude(b, boston, jd0, tSet)
+	if math.Abs((h-h0).Deg()) > tol.Deg() || H < 0 {
+		t.Error("set:", h.Deg(), H.Hour())
+	}
+	if _, H = altitude(b, boston, jd0, tTransit); math.Abs(H.Angle().Deg()) > tol.Deg() {
+		t.Error("transit:", H.Hour())
+	}
+}
+
+func TestSun(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tRise, tTransit, tSet, err := rise.Sun(1988, 3, 20, boston, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jd0 := julian.CalendarGregorianToJD(1988, 3, 20)
+	checkTimes(t, body.Sun{Earth: e}, jd0, rise.Stdh0Solar,
+		tRise, tTransit, tSet, unit.AngleFromMin(1))
+	// 4ʰ44ᵐ for longitude, 7.5ᵐ for the equation of time.
+	if d := tTransit - unit.NewTime(' ', 16, 51, 46); math.Abs(d.Min()) > 1 {
+		t.Error("transit:", tTransit.Hour())
+	}
+}
+
+func TestMoon(t *testing.T) {
+	tRise, tTransit, tSet, err := rise.Moon(1988, 3, 20, boston)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jd0 := julian.CalendarGregorianToJD(1988, 3, 20)
+	_, _, Δ := body.Moon{}.Apparent(jd0)
+	h0 := rise.Stdh0Lunar(unit.Angle(math.Asin(6378.14 / (Δ * 149597870))))
+	checkTimes(t, body.Moon{}, jd0, h0, tRise, tTransit, tSet,
+		unit.AngleFromMin(2))
+}
+
+func TestStar(t *testing.T) {
+	// θ Persei, Example 23.a
+	s := body.Star{
+		RA:    unit.NewRA(2, 44, 11.986),
+		Dec:   unit.NewAngle(' ', 49, 13, 42.48),
+		Epoch: 2000,
+		PMRA:  unit.HourAngleFromSec(.03425),
+		PMDec: unit.AngleFromSec(-.0895),
+	}
+	// θ Persei is circumpolar at Boston.
+	if _, _, _, err := rise.Star(2028, 11, 13, boston, s); err != rise.ErrorCircumpolar {
+		t.Fatal(err)
+	}
+	// Moved south, it rises and sets.
+	s.Dec = unit.AngleFromDeg(-30)
+	tRise, tTransit, tSet, err := rise.Star(2028, 11, 13, boston, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTimes(t, s, julian.CalendarGregorianToJD(2028, 11, 13),
+		rise.Stdh0Stellar, tRise, tTransit, tSet, unit.AngleFromMin(1))
+}
+
+func TestElements(t *testing.T) {
+	// Comet Encke, Example 33.b
+	k := &elliptic.Elements{
+		TimeP: julian.CalendarGregorianToJD(1990, 10, 28.54502),
+		Axis:  2.2091404,
+		Ecc:   .8502196,
+		Inc:   unit.AngleFromDeg(11.94524),
+		Node:  unit.AngleFromDeg(334.75006),
+		ArgP:  unit.AngleFromDeg(186.23352),
+	}
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tRise, tTransit, tSet, err := rise.Elements(1990, 10, 6, boston, e, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkTimes(t, body.NewElliptic(k, e),
+		julian.CalendarGregorianToJD(1990, 10, 6),
+		rise.Stdh0Stellar, tRise, tTransit, tSet, unit.AngleFromMin(1))
+}
+
+func TestTwilightTimes(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jd0 := julian.CalendarGregorianToJD(1988, 3, 20)
+	for _, tw := range []rise.Twilight{rise.Civil, rise.Nautical, rise.Astronomical} {
+		tDawn, tDusk, err := rise.TwilightTimes(1988, 3, 20, boston, e, tw)
+		if err != nil {
+			t.Fatal(tw, err)
+		}
+		sun := body.Sun{Earth: e}
+		if h, H := altitude(sun, boston, jd0, tDawn); math.Abs((h-tw.H0()).Min()) > 1 || H > 0 {
+			t.Error(tw, "dawn:", h.Deg(), H.Hour())
+		}
+		if h, H := altitude(sun, boston, jd0, tDusk); math.Abs((h-tw.H0()).Min()) > 1 || H < 0 {
+			t.Error(tw, "dusk:", h.Deg(), H.Hour())
+		}
+	}
+	// At latitude 60° north near midsummer the Sun descends only to about
+	// 6.5° below the horizon.
+	p := globe.Coord{Lat: unit.AngleFromDeg(60)}
+	if _, _, err := rise.TwilightTimes(1988, 6, 21, p, e, rise.Civil); err != nil {
+		t.Error(err)
+	}
+	if _, _, err := rise.TwilightTimes(1988, 6, 21, p, e, rise.Nautical); err != rise.ErrorNoNight {
+		t.Error("nautical:", err)
+	}
+	// In polar night the Sun does not rise even to 6° below the horizon.
+	p.Lat = unit.AngleFromDeg(80)
+	if _, _, err := rise.TwilightTimes(1988, 12, 21, p, e, rise.Civil); err != rise.ErrorCircumpolar {
+		t.Error("polar night:", err)
+	}
+}
+
+func ExampleTwilight_String() {
+	fmt.Println(rise.Astronomical, rise.Astronomical.H0().Deg())
+	// Output:
+	// astronomical -18
+}
+
+func TestEvents(t *testing.T) {
+	// Moon at Boston for a week.  The Moon sets after midnight on some
+	// days, so the days hold different numbers of events.
+	jd1 := julian.CalendarGregorianToJD(1988, 3, 18)
+	jd2 := jd1 + 7
+	h0 := rise.Stdh0LunarMean
+	events, _ := rise.Events(body.Moon{}, boston, h0, jd1, jd2)
+	var n [4]int
+	for i, e := range events {
+		if i > 0 && e.JD <= events[i-1].JD {
+			t.Fatal("events out of order")
+		}
+		if e.JD < jd1 || e.JD > jd2 {
+			t.Fatal("event out of range")
+		}
+		n[e.Kind]++
+		day := math.Floor(e.JD-.5) + .5
+		h, H := altitude(body.Moon{}, boston, day,
+			unit.TimeFromDay(e.JD-day))
+		switch e.Kind {
+		case rise.Rising, rise.Setting:
+			if math.Abs((h - h0).Sec()) > 1 {
+				t.Error(e.Kind, h.Deg())
+			}
+		case rise.Transit:
+			if math.Abs(H.Angle().Sec()) > 1 {
+				t.Error(e.Kind, H.Hour())
+			}
+		case rise.LowerTransit:
+			if math.Abs(math.Abs(H.Angle().Deg())-180) > 1e-3 {
+				t.Error(e.Kind, H.Hour())
+			}
+		}
+	}
+	// the Moon transits about 50 minutes later each day
+	for k, c := range n {
+		if c < 6 || c > 7 {
+			t.Error(rise.EventKind(k), c)
+		}
+	}
+	// rise.Moon agrees with the first rising
+	tRise, _, _, err := rise.Moon(1988, 3, 18, boston)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range events {
+		if e.Kind == rise.Rising {
+			if d := unit.TimeFromDay(e.JD-jd1) - tRise; math.Abs(d.Min()) > 1 {
+				t.Error("rise.Moon differs by", d)
+			}
+			break
+		}
+	}
+}
+
+func TestEventsPolar(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sun := body.Sun{Earth: e}
+	p := globe.Coord{Lat: unit.AngleFromDeg(70)}
+	// midnight Sun
+	jd := julian.CalendarGregorianToJD(1988, 6, 21)
+	events, up := rise.Events(sun, p, rise.Stdh0Solar, jd, jd+1)
+	if !up || len(events) != 2 ||
+		events[0].Kind != rise.LowerTransit || events[1].Kind != rise.Transit {
+		t.Error("summer:", up, events)
+	}
+	// polar night
+	jd = julian.CalendarGregorianToJD(1988, 12, 21)
+	events, up = rise.Events(sun, p, rise.Stdh0Solar, jd, jd+1)
+	if up || len(events) != 2 {
+		t.Error("winter:", up, events)
+	}
+}
+
+func ExampleEventKind_String() {
+	fmt.Println(rise.Rising, rise.LowerTransit)
+	// Output:
+	// rising lower transit
+}
@@ -25,7 +25,7 @@ const d = math.Pi / 180
 // Results returned in argument pos, which must not be nil.
 //
 // Result units are Saturn radii.
-func Positions(jde float64, earth, saturn *pp.V87Planet, pos *[8]XY) {
+func Positions(jde float64, earth, saturn pp.Planet, pos *[8]XY) {
 	s, β, R := solar.TrueVSOP87(earth, jde)
 	ss, cs := s.Sincos()
 	sβ := β.Sin()
@@ -30,23 +30,42 @@ const (
 //	P  Geometric position angle of the northern semiminor axis of the ring.
 //	aEdge  Major axis of the out edge of the outer ring.
 //	bEdge  Minor axis of the out edge of the outer ring.
-func Ring(jde float64, earth, saturn *pp.V87Planet) (B, Bʹ, ΔU, P, aEdge, bEdge unit.Angle) {
+func Ring(jde float64, earth, saturn pp.Planet) (B, Bʹ, ΔU, P, aEdge, bEdge unit.Angle) {
 	f1, f2 := cl(jde, earth, saturn)
 	ΔU, B = f1()
 	Bʹ, P, aEdge, bEdge = f2()
 	return
 }
 
+// Aspect holds the results of Ring.
+//
+// Field tags give names for encoding/json.  Angles are encoded as radians.
+type Aspect struct {
+	B      unit.Angle `json:"latEarth"`  // Saturnicentric latitude of the Earth
+	BPrime unit.Angle `json:"latSun"`    // Bʹ, Saturnicentric latitude of the Sun
+	DeltaU unit.Angle `json:"deltaLon"`  // ΔU, difference of Saturnicentric longitudes
+	P      unit.Angle `json:"posAngle"`  // position angle of the northern semiminor axis
+	AEdge  unit.Angle `json:"majorAxis"` // major axis of the outer edge of the outer ring
+	BEdge  unit.Angle `json:"minorAxis"` // minor axis of the outer edge of the outer ring
+}
+
+// RingAspect computes the quantities of Ring and returns them as an Aspect.
+// 土星光环，结果以结构体返回
+func RingAspect(jde float64, earth, saturn pp.Planet) (a Aspect) {
+	a.B, a.BPrime, a.DeltaU, a.P, a.AEdge, a.BEdge = Ring(jde, earth, saturn)
+	return
+}
+
 // UB computes quantities required by illum.Saturn().
 //
 // Same as ΔU and B returned by Ring().  Results in radians.
-func UB(jde float64, earth, saturn *pp.V87Planet) (ΔU, B unit.Angle) {
+func UB(jde float64, earth, saturn pp.Planet) (ΔU, B unit.Angle) {
 	f1, _ := cl(jde, earth, saturn)
 	return f1()
 }
 
 // cl splits the work into two closures.
-func cl(jde float64, earth, saturn *pp.V87Planet) (f1 func() (ΔU, B unit.Angle),
+func cl(jde float64, earth, saturn pp.Planet) (f1 func() (ΔU, B unit.Angle),
 	f2 func() (Bʹ, P, aEdge, bEdge unit.Angle)) {
 	const p = math.Pi / 180
 	var i, Ω unit.Angle
@@ -0,0 +1,50 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package saturnring_test
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
+	"github.com/mooncaker816/learnmeeus/v3/saturnring"
+)
+
+func TestRingAspect(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := pp.NewKeplerian(pp.Saturn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Example 45.a, p. 320.
+	const jde = 2448972.50068
+	B, Bʹ, ΔU, P, a, b := saturnring.Ring(jde, e, s)
+	got := saturnring.RingAspect(jde, e, s)
+	if got != (saturnring.Aspect{B: B, BPrime: Bʹ, DeltaU: ΔU, P: P,
+		AEdge: a, BEdge: b}) {
+		t.Fatal(got)
+	}
+	// the ring is seen as an ellipse of minor axis a sin |B|
+	if r := got.BEdge.Rad() / got.AEdge.Rad(); math.Abs(r-math.Abs(got.B.Sin())) > 1e-12 {
+		t.Error(r, got.B.Sin())
+	}
+}
+
+func TestAspectUnmarshal(t *testing.T) {
+	var a saturnring.Aspect
+	err := json.Unmarshal([]byte(`{"latEarth":0.287,"latSun":0.256,
+		"deltaLon":0.0733,"posAngle":0.118,
+		"majorAxis":1.739e-4,"minorAxis":4.92e-5}`), &a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.B != .287 || a.BPrime != .256 || a.DeltaU != .0733 ||
+		a.P != .118 || a.AEdge != 1.739e-4 || a.BEdge != 4.92e-5 {
+		t.Error(a)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"math"
 
 	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
 	"github.com/mooncaker816/learnmeeus/v3/nutation"
 	"github.com/soniakeys/unit"
 )
@@ -87,3 +88,32 @@ func Apparent0UT(jd float64) unit.Time {
 	n := nutation.NutationInRA(j0) // HourAngle
 	return (s + n.Time()).Mod1()
 }
+
+// Mean2 returns mean sidereal time at Greenwich for a julian day given in
+// two parts.
+//
+// The fraction of the day is kept apart from the julian day of 0h UT, so
+// that the result keeps the full precision of j.Frac.
+// The result is in the range [0,86400).
+// 计算格林威治两部分儒略日时刻的瞬时平恒星时
+func Mean2(j julian.JD2) unit.Time {
+	return mean2(j).Mod1()
+}
+
+// 计算格林威治两部分儒略日时刻的瞬时平恒星时
+func mean2(j julian.JD2) unit.Time {
+	j = julian.NewJD2(j.Day, j.Frac)
+	// (12.2) p. 87
+	s := unit.Time(base.Horner(base.J2000Century(j.Day), iau82...))
+	return s + unit.TimeFromDay(j.Frac*1.00273790935)
+}
+
+// Apparent2 returns apparent sidereal time at Greenwich for a julian day
+// given in two parts.
+//
+// The result is in the range [0,86400).
+// 计算格林威治两部分儒略日时刻的瞬时视恒星时
+func Apparent2(j julian.JD2) unit.Time {
+	n := nutation.NutationInRA(j.JD()) // HourAngle
+	return (mean2(j) + n.Time()).Mod1()
+}
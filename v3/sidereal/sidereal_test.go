@@ -31,3 +31,13 @@ func ExampleMean_b() {
 	// Output:
 	// 8ʰ34ᵐ57ˢ.0896
 }
+
+func ExampleMean2() {
+	// Example 12.b, p. 89, with a two-part julian day.
+	j := julian.TimeToJD2(time.Date(1987, 4, 10, 19, 21, 0, 0, time.UTC))
+	fmt.Printf("%.4d\n", sexa.FmtTime(sidereal.Mean2(j)))
+	fmt.Printf("%.4d\n", sexa.FmtTime(sidereal.Apparent2(j)))
+	// Output:
+	// 8ʰ34ᵐ57ˢ.0896
+	// 8ʰ34ᵐ56ˢ.8531
+}
@@ -156,7 +156,7 @@ func ApparentEquatorial(jde float64) (α unit.RA, δ unit.Angle) {
 //	s: ecliptic longitude
 //	β: ecliptic latitude
 //	R: range in AU
-func TrueVSOP87(e *pp.V87Planet, jde float64) (s, β unit.Angle, R float64) {
+func TrueVSOP87(e pp.Planet, jde float64) (s, β unit.Angle, R float64) {
 	l, b, r := e.Position(jde) //VSOP87算出的地球的日心黄经，黄纬，日地距离
 	s = l + math.Pi
 	// FK5 correction.
@@ -179,7 +179,7 @@ func TrueVSOP87(e *pp.V87Planet, jde float64) (s, β unit.Angle, R float64) {
 //  λ: ecliptic longitude
 //  β: ecliptic latitude
 //  R: range in AU
-func ApparentVSOP87(e *pp.V87Planet, jde float64) (λ, β unit.Angle, R float64) {
+func ApparentVSOP87(e pp.Planet, jde float64) (λ, β unit.Angle, R float64) {
 	// note: see duplicated code in ApparentEquatorialVSOP87.
 	s, β, R := TrueVSOP87(e, jde)
 	Δψ, _ := nutation.Nutation(jde)
@@ -197,7 +197,7 @@ func ApparentVSOP87(e *pp.V87Planet, jde float64) (λ, β unit.Angle, R float64)
 //	α: right ascension
 //	δ: declination
 //	R: range in AU
-func ApparentEquatorialVSOP87(e *pp.V87Planet, jde float64) (α unit.RA, δ unit.Angle, R float64) {
+func ApparentEquatorialVSOP87(e pp.Planet, jde float64) (α unit.RA, δ unit.Angle, R float64) {
 	// note: duplicate code from ApparentVSOP87 so we can keep Δε.
 	// see also duplicate code in time.E().
 	s, β, R := TrueVSOP87(e, jde)
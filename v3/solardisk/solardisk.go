@@ -21,7 +21,7 @@ import (
 //	P:  Position angle of the solar north pole.
 //	B0: Heliographic latitude of the center of the solar disk.
 //	L0: Heliographic longitude of the center of the solar disk.
-func Ephemeris(jd float64, e *pp.V87Planet) (P, B0, L0 unit.Angle) {
+func Ephemeris(jd float64, e pp.Planet) (P, B0, L0 unit.Angle) {
 	θ := unit.Angle((jd - 2398220) * 2 * math.Pi / 25.38)
 	I := unit.AngleFromDeg(7.25)
 	K := unit.AngleFromDeg(73.6667) +
@@ -17,7 +17,7 @@ import (
 // Position returns rectangular coordinates referenced to the mean equinox
 // of date.
 // Date 平分点太阳地心直角坐标
-func Position(e *pp.V87Planet, jde float64) (x, y, z float64) {
+func Position(e pp.Planet, jde float64) (x, y, z float64) {
 	// (26.1) p. 171
 	s, β, R := solar.TrueVSOP87(e, jde)
 	sε, cε := nutation.MeanObliquity(jde).Sincos()
@@ -31,14 +31,14 @@ func Position(e *pp.V87Planet, jde float64) (x, y, z float64) {
 
 // LongitudeJ2000 returns geometric longitude referenced to equinox J2000.
 // J2000太阳地心黄经
-func LongitudeJ2000(e *pp.V87Planet, jde float64) (l unit.Angle) {
+func LongitudeJ2000(e pp.Planet, jde float64) (l unit.Angle) {
 	l, _, _ = e.Position2000(jde)
 	return (l + math.Pi - unit.AngleFromSec(.09033)).Mod1()
 }
 
 // PositionJ2000 returns rectangular coordinates referenced to equinox J2000.
 // J2000太阳直角坐标
-func PositionJ2000(e *pp.V87Planet, jde float64) (x, y, z float64) {
+func PositionJ2000(e pp.Planet, jde float64) (x, y, z float64) {
 	x, y, z = xyz(e, jde)
 	// (26.3) p. 174
 	return x + .00000044036*y - .000000190919*z,
@@ -46,7 +46,7 @@ func PositionJ2000(e *pp.V87Planet, jde float64) (x, y, z float64) {
 		.397776982902*y + .917482137087*z
 }
 
-func xyz(e *pp.V87Planet, jde float64) (x, y, z float64) {
+func xyz(e pp.Planet, jde float64) (x, y, z float64) {
 	l, b, r := e.Position2000(jde)
 	s := l + math.Pi
 	β := -b
@@ -64,7 +64,7 @@ func xyz(e *pp.V87Planet, jde float64) (x, y, z float64) {
 //
 // Results are referenced to the mean equator and equinox of the epoch B1950
 // in the FK5 system, not FK4.
-func PositionB1950(e *pp.V87Planet, jde float64) (x, y, z float64) {
+func PositionB1950(e pp.Planet, jde float64) (x, y, z float64) {
 	x, y, z = xyz(e, jde)
 	return .999925702634*x + .012189716217*y + .000011134016*z,
 		-.011179418036*x + .917413998946*y - .397777041885*z,
@@ -82,7 +82,7 @@ var (
 //
 // Position will be computed for given Julian day "jde" but referenced to mean
 // equinox "epoch" (year).
-func PositionEquinox(e *pp.V87Planet, jde, epoch float64) (xp, yp, zp float64) {
+func PositionEquinox(e pp.Planet, jde, epoch float64) (xp, yp, zp float64) {
 	x0, y0, z0 := PositionJ2000(e, jde)
 	t := (epoch - 2000) * .01
 	ζ := base.Horner(t, ζt...) * t * math.Pi / 180 / 3600
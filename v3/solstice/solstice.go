@@ -8,6 +8,7 @@ import (
 	"math"
 
 	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
 	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
 	"github.com/mooncaker816/learnmeeus/v3/solar"
 	"github.com/soniakeys/unit"
@@ -125,9 +126,10 @@ func eq(y int, c []float64) float64 {
 // March2 returns a more accurate JDE of the March equinox.
 // 高精度计算春分点力学时
 //
-// Result is accurate to one second of time.
+// Result is accurate to one second of time with VSOP87 theory, to a few
+// minutes with planetposition.Keplerian.
 //
-// Parameter e must be a V87Planet object representing Earth, obtained with
+// Parameter e must be a Planet object representing Earth, obtained with
 // the package planetposition and code similar to
 //
 //	e, err := planetposition.LoadPlanet(planetposition.Earth, "")
@@ -135,7 +137,7 @@ func eq(y int, c []float64) float64 {
 //	        ....
 //
 // See example under June2.
-func March2(y int, e *pp.V87Planet) float64 {
+func March2(y int, e pp.Planet) float64 {
 	if y < 1000 {
 		return eq2(y, e, 0, mc0)
 	}
@@ -145,11 +147,12 @@ func March2(y int, e *pp.V87Planet) float64 {
 // June2 returns a more accurate JDE of the June solstice.
 // 高精度计算夏至点力学时
 //
-// Result is accurate to one second of time.
+// Result is accurate to one second of time with VSOP87 theory, to a few
+// minutes with planetposition.Keplerian.
 //
-// Parameter e must be a V87Planet object representing Earth, obtained with
+// Parameter e must be a Planet object representing Earth, obtained with
 // the package planetposition.
-func June2(y int, e *pp.V87Planet) float64 {
+func June2(y int, e pp.Planet) float64 {
 	if y < 1000 {
 		return eq2(y, e, math.Pi/2, jc0)
 	}
@@ -159,9 +162,10 @@ func June2(y int, e *pp.V87Planet) float64 {
 // September2 returns a more accurate JDE of the September equinox.
 // 高精度计算秋分点力学时
 //
-// Result is accurate to one second of time.
+// Result is accurate to one second of time with VSOP87 theory, to a few
+// minutes with planetposition.Keplerian.
 //
-// Parameter e must be a V87Planet object representing Earth, obtained with
+// Parameter e must be a Planet object representing Earth, obtained with
 // the package planetposition and code similar to
 //
 //	e, err := planetposition.LoadPlanet(planetposition.Earth, "")
@@ -169,7 +173,7 @@ func June2(y int, e *pp.V87Planet) float64 {
 //	        ....
 //
 // See example under June2.
-func September2(y int, e *pp.V87Planet) float64 {
+func September2(y int, e pp.Planet) float64 {
 	if y < 1000 {
 		return eq2(y, e, math.Pi, sc0)
 	}
@@ -179,9 +183,10 @@ func September2(y int, e *pp.V87Planet) float64 {
 // December2 returns a more accurate JDE of the December solstice.
 // 高精度计算冬至点力学时
 //
-// Result is accurate to one second of time.
+// Result is accurate to one second of time with VSOP87 theory, to a few
+// minutes with planetposition.Keplerian.
 //
-// Parameter e must be a V87Planet object representing Earth, obtained with
+// Parameter e must be a Planet object representing Earth, obtained with
 // the package planetposition and code similar to
 //
 //	e, err := planetposition.LoadPlanet(planetposition.Earth, "")
@@ -189,7 +194,7 @@ func September2(y int, e *pp.V87Planet) float64 {
 //	        ....
 //
 // See example under June2.
-func December2(y int, e *pp.V87Planet) float64 {
+func December2(y int, e pp.Planet) float64 {
 	if y < 1000 {
 		return eq2(y, e, math.Pi*3/2, dc0)
 	}
@@ -198,8 +203,13 @@ func December2(y int, e *pp.V87Planet) float64 {
 
 //先用低精度方法算出近似时刻，再采用VSOP87理论计算出该时刻的太阳视黄经λ，
 //再根据各个分至点的几何度数求该近似时刻的修正量，循环迭代，直至满足要求。
-func eq2(y int, e *pp.V87Planet, q unit.Angle, c []float64) float64 {
-	J0 := base.Horner(float64(y)*.001, c...)
+func eq2(y int, e pp.Planet, q unit.Angle, c []float64) float64 {
+	return longitude(e, q, base.Horner(float64(y)*.001, c...))
+}
+
+// longitude iterates from J0 to the JDE when the apparent solar longitude
+// is q.
+func longitude(e pp.Planet, q unit.Angle, J0 float64) float64 {
 	for {
 		λ, _, _ := solar.ApparentVSOP87(e, J0)
 		c := 58 * (q - λ).Sin() // (27.1) p. 180
@@ -210,3 +220,83 @@ func eq2(y int, e *pp.V87Planet, q unit.Angle, c []float64) float64 {
 	}
 	return J0
 }
+
+// Longitude returns the JDE in Gregorian year y when the apparent
+// geocentric longitude of the Sun is λ.
+// 计算y年太阳视黄经为λ的力学时
+//
+// The result is computed by the method of March2 and so is accurate to one
+// second of time with full VSOP87 theory, a few minutes with
+// planetposition.Keplerian.  Parameter e must be a Planet
+// object representing Earth, obtained with the package planetposition.
+//
+// The year is bounded by 0h January 1 in dynamical time.
+func Longitude(y int, λ unit.Angle, e pp.Planet) float64 {
+	// days from the March equinox, for λ in the range -80° to 280°
+	d := math.Mod(λ.Deg()+80, 360)
+	if d < 0 {
+		d += 360
+	}
+	J := longitude(e, λ, March(y)+(d-80)*365.2422/360)
+	switch {
+	case J < julian.CalendarGregorianToJD(y, 1, 1):
+		J = longitude(e, λ, J+365.2422)
+	case J >= julian.CalendarGregorianToJD(y+1, 1, 1):
+		J = longitude(e, λ, J-365.2422)
+	}
+	return J
+}
+
+// Crossing is a time when the apparent solar longitude reaches a multiple
+// of the step of Crossings.
+type Crossing struct {
+	JDE       float64
+	Longitude unit.Angle // apparent longitude, in the range [0, 2π)
+}
+
+// Crossings returns times from jde1 up to jde2 when the apparent geocentric
+// longitude of the Sun reaches a multiple of step.
+// 计算时段内太阳视黄经到达step整数倍的时刻
+//
+// Step must be positive and divide 360° evenly, otherwise the result is
+// nil.  A step of 15° gives the 24 solar terms, 30° the ingresses of the
+// Sun into the signs of the zodiac, and 45° the equinoxes, solstices, and
+// cross-quarter days.  Parameter e must be a Planet object representing
+// Earth, obtained with the package planetposition.
+func Crossings(jde1, jde2 float64, step unit.Angle, e pp.Planet) (c []Crossing) {
+	if !(step > 0) {
+		return nil
+	}
+	// crossings in a full circle
+	per := int(math.Round(2 * math.Pi / step.Rad()))
+	if math.Abs(step.Rad()*float64(per)-2*math.Pi) > 1e-9 {
+		return nil
+	}
+	λ, _, _ := solar.ApparentVSOP87(e, jde1)
+	// the next crossing
+	n := int(math.Floor(λ.Rad()/step.Rad())) + 1
+	J := jde1 + (step.Mul(float64(n))-λ).Rad()/(2*math.Pi)*365.2422
+	for ; ; n++ {
+		J = longitude(e, step.Mul(float64(n)), J)
+		if J >= jde2 {
+			return
+		}
+		c = append(c, Crossing{J, step.Mul(float64(n % per))})
+		J += step.Rad() / (2 * math.Pi) * 365.2422
+	}
+}
+
+// SolarTerms returns times from jde1 up to jde2 of the 24 solar terms,
+// when the apparent longitude of the Sun is a multiple of 15°.
+// 计算时段内的二十四节气
+func SolarTerms(jde1, jde2 float64, e pp.Planet) []Crossing {
+	return Crossings(jde1, jde2, unit.AngleFromDeg(15), e)
+}
+
+// Ingresses returns times from jde1 up to jde2 of the ingresses of the Sun
+// into the signs of the zodiac, when its apparent longitude is a multiple
+// of 30°.
+// 计算时段内太阳进入黄道十二宫的时刻
+func Ingresses(jde1, jde2 float64, e pp.Planet) []Crossing {
+	return Crossings(jde1, jde2, unit.AngleFromDeg(30), e)
+}
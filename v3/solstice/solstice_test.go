@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/mooncaker816/learnmeeus/v3/julian"
+	pp "github.com/mooncaker816/learnmeeus/v3/planetposition"
 	"github.com/mooncaker816/learnmeeus/v3/solstice"
 	"github.com/soniakeys/unit"
 )
@@ -124,3 +125,73 @@ func Test2000(t *testing.T) {
 		}
 	}
 }
+
+func TestJune2Keplerian(t *testing.T) {
+	// Example 27.b, p. 180, with the built in Keplerian theory in place of
+	// full VSOP87.
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := 2437836.5 + unit.NewTime(' ', 21, 24, 42).Day()
+	if Δ := solstice.June2(1962, e) - want; math.Abs(Δ) > 10./(24*60) {
+		t.Fatal("error:", unit.TimeFromDay(Δ).Min(), "minutes")
+	}
+}
+
+func TestLongitude(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 270° must agree with December2
+	if Δ := solstice.Longitude(1999, unit.AngleFromDeg(270), e) -
+		solstice.December2(1999, e); math.Abs(Δ) > 1e-6 {
+		t.Error("Δ", Δ)
+	}
+	// longitudes at year end stay in the year
+	for _, λ := range []float64{279, 281} {
+		j := solstice.Longitude(2001, unit.AngleFromDeg(λ), e)
+		if y, _, _ := julian.JDToCalendar(j); y != 2001 {
+			t.Error(λ, y)
+		}
+	}
+}
+
+func TestCrossingsStep(t *testing.T) {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	j1 := julian.CalendarGregorianToJD(2000, 1, 1)
+	for _, step := range []float64{0, -15, 7, 400} {
+		if c := solstice.Crossings(j1, j1+365, unit.AngleFromDeg(step), e); c != nil {
+			t.Error(step, len(c))
+		}
+	}
+	if c := solstice.Crossings(j1, j1+365, unit.AngleFromDeg(360), e); len(c) != 1 ||
+		c[0].Longitude != 0 {
+		t.Error(c)
+	}
+}
+
+func ExampleSolarTerms() {
+	e, err := pp.NewKeplerian(pp.Earth)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, c := range solstice.SolarTerms(
+		julian.CalendarGregorianToJD(2000, 1, 1),
+		julian.CalendarGregorianToJD(2000, 4, 1), e) {
+		y, m, d := julian.JDToCalendar(c.JDE)
+		fmt.Printf("%3.0f° %d-%02d-%05.2f\n", c.Longitude.Deg(), y, m, d)
+	}
+	// Output:
+	// 285° 2000-01-06.04
+	// 300° 2000-01-20.76
+	// 315° 2000-02-04.53
+	// 330° 2000-02-19.35
+	// 345° 2000-03-05.28
+	//   0° 2000-03-20.31
+}
@@ -0,0 +1,276 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package timescale
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+)
+
+// ErrorSyntax is returned by Parse and ParseDuration for text that is not
+// a valid date or duration.
+var ErrorSyntax = errors.New("Invalid date or duration syntax")
+
+// isoDate matches an ISO 8601 date and time of extended format, with an
+// optional sign and more than four digits of year.
+var isoDate = regexp.MustCompile(`^([+-]?\d{4,})-(\d\d)-(\d\d)` +
+	`(?:T(\d\d):(\d\d)(?::(\d\d(?:\.\d+)?))?(Z|[+-]\d\d:\d\d)?)?$`)
+
+// parseScale returns the Scale of an abbreviation such as "TT".
+func parseScale(s string) (Scale, error) {
+	for i, n := range scaleNames {
+		if strings.EqualFold(s, n) {
+			return Scale(i), nil
+		}
+	}
+	return 0, ErrorSyntax
+}
+
+// Parse parses a date and returns its julian day.
+// 解析 ISO 8601 日期或儒略日字符串
+//
+// The date may be given in ISO 8601 extended format, as "2000-01-01",
+// "2000-01-01T12:00" or "-0500-03-01T12:00:00.25" for example.  Years are
+// numbered astronomically in the proleptic Gregorian calendar, as ISO 8601
+// specifies, and may have a sign and more than four digits.  The time may
+// be followed by "Z" or by an offset from UTC such as "+08:00".  A leap
+// second, "2016-12-31T23:59:60Z" for example, is accepted in UTC when the
+// table Leap has it.  A UTC julian day cannot represent a leap second, so
+// any time within it is given the julian day of the 0h that follows.
+//
+// The date may otherwise be given as a julian day, as "JD 2451545" or
+// "2451545.0", or as a modified julian day, as "MJD 51544.5".
+//
+// Either form may be followed by a space and the abbreviation of a time
+// scale, as "2000-01-01T12:00:00 TT".  The scale is UTC if not given.
+// "Z", an offset from UTC, or a leap second is valid only in UTC.
+func Parse(s string) (JD, error) {
+	f := strings.Fields(s)
+	// prefix JD or MJD, separate or not
+	var prefix string
+	if len(f) > 0 {
+		for _, p := range []string{"MJD", "JD"} {
+			if strings.HasPrefix(strings.ToUpper(f[0]), p) {
+				prefix = p
+				if f[0] = f[0][len(p):]; f[0] == "" {
+					f = f[1:]
+				}
+				break
+			}
+		}
+	}
+	if len(f) == 0 || len(f) > 2 {
+		return JD{}, ErrorSyntax
+	}
+	j := JD{Scale: UTC}
+	var zone, leap bool // "Z" or an offset from UTC, a leap second
+	var err error
+	if m := isoDate.FindStringSubmatch(f[0]); m != nil && prefix == "" {
+		if j.JD, zone, leap, err = parseISO(m); err != nil {
+			return JD{}, err
+		}
+	} else if j.JD, err = strconv.ParseFloat(f[0], 64); err != nil {
+		return JD{}, ErrorSyntax
+	} else if prefix == "MJD" {
+		j.JD += base.JMod
+	}
+	if len(f) == 2 {
+		if j.Scale, err = parseScale(f[1]); err != nil {
+			return JD{}, err
+		}
+		if (zone || leap) && j.Scale != UTC {
+			return JD{}, ErrorSyntax
+		}
+	}
+	return j, nil
+}
+
+// monthDays returns the number of days in month m of Gregorian year y.
+func monthDays(y, m int) int {
+	if m == 2 && julian.LeapYearGregorian(y) {
+		return 29
+	}
+	return [...]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}[m-1]
+}
+
+// parseISO returns the julian day of the submatches of isoDate, whether
+// "Z" or an offset from UTC was given, and whether the time is in a leap
+// second of UTC.
+func parseISO(m []string) (jd float64, zone, leap bool, err error) {
+	y, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false, false, ErrorSyntax
+	}
+	mo, _ := strconv.Atoi(m[2])
+	d, _ := strconv.Atoi(m[3])
+	if mo < 1 || mo > 12 || d < 1 || d > monthDays(y, mo) {
+		return 0, false, false, ErrorSyntax
+	}
+	var h, mi int
+	var sec float64
+	if m[4] != "" {
+		h, _ = strconv.Atoi(m[4])
+		mi, _ = strconv.Atoi(m[5])
+		if m[6] != "" {
+			sec, _ = strconv.ParseFloat(m[6], 64)
+		}
+		if h > 23 || mi > 59 || sec >= 61 {
+			return 0, false, false, ErrorSyntax
+		}
+		leap = sec >= 60
+	}
+	switch z := m[7]; len(z) {
+	case 1:
+		zone = true
+	case 6:
+		oh, _ := strconv.Atoi(z[1:3])
+		om, _ := strconv.Atoi(z[4:])
+		if oh > 23 || om > 59 {
+			return 0, false, false, ErrorSyntax
+		}
+		off := oh*60 + om
+		if z[0] == '-' {
+			off = -off
+		}
+		mi -= off
+		zone = true
+	}
+	jd = julian.CalendarGregorianToJD(y, mo, float64(d))
+	if leap {
+		// the 0h UTC that follows the leap second
+		jd += float64(h*60+mi+1) / 1440
+		if !Leap.leapSecondBefore(jd) {
+			return 0, false, false, ErrorSyntax
+		}
+		return jd, zone, leap, nil
+	}
+	jd += (float64(h*3600+mi*60) + sec) / 86400
+	return jd, zone, leap, nil
+}
+
+// ISO formats the julian day as a date and time of ISO 8601 extended
+// format followed by the scale, as "2000-01-01T12:00:00.000 TT".
+// 格式化为 ISO 8601 日期
+//
+// Argument digits is the number of decimal places of seconds, from 0 to 9.
+// The date is in the proleptic Gregorian calendar with years numbered
+// astronomically, as accepted by Parse.
+func (j JD) ISO(digits int) string {
+	if digits < 0 {
+		digits = 0
+	} else if digits > 9 {
+		digits = 9
+	}
+	p := int64(math.Pow10(digits))
+	day := int64(86400) * p
+	z := math.Floor(j.JD + .5)
+	u := int64(math.Round((j.JD + .5 - z) * float64(day)))
+	if u >= day {
+		z++
+		u -= day
+	}
+	y, m, d := julian.JDToCalendarGregorian(z - .5)
+	var ys string
+	switch {
+	case y < 0:
+		ys = fmt.Sprintf("-%04d", -y)
+	case y > 9999:
+		ys = fmt.Sprintf("+%d", y)
+	default:
+		ys = fmt.Sprintf("%04d", y)
+	}
+	s := fmt.Sprintf("%s-%02d-%02dT%02d:%02d:%02d", ys, m, int(d),
+		u/(3600*p), u/(60*p)%60, u/p%60)
+	if digits > 0 {
+		s += fmt.Sprintf(".%0*d", digits, u%p)
+	}
+	return s + " " + j.Scale.String()
+}
+
+// FormatJD formats the julian day as "JD 2451545.000000 TT", with the
+// given number of decimal places.
+func (j JD) FormatJD(digits int) string {
+	return fmt.Sprintf("JD %.*f %s", digits, j.JD, j.Scale)
+}
+
+// FormatMJD formats the modified julian day as "MJD 51544.500000 TT",
+// with the given number of decimal places.
+func (j JD) FormatMJD(digits int) string {
+	return fmt.Sprintf("MJD %.*f %s", digits, j.JD-base.JMod, j.Scale)
+}
+
+// isoDuration matches an ISO 8601 duration of years, weeks, days, hours,
+// minutes, and seconds, with an optional sign.
+var isoDuration = regexp.MustCompile(`^([+-])?P(?:(\d+(?:\.\d+)?)Y)?` +
+	`(?:(\d+(?:\.\d+)?)W)?(?:(\d+(?:\.\d+)?)D)?` +
+	`(?:T(?:(\d+(?:\.\d+)?)H)?(?:(\d+(?:\.\d+)?)M)?(?:(\d+(?:\.\d+)?)S)?)?$`)
+
+// ParseDuration parses an ISO 8601 duration, "P1DT12H" or "-PT0.5S" for
+// example, and returns it in days.
+// 解析 ISO 8601 时间段，单位为日
+//
+// Years are Julian years of 365.25 days.  Months, of no fixed length, are
+// not accepted.  Any component may have a decimal fraction.
+func ParseDuration(s string) (float64, error) {
+	m := isoDuration.FindStringSubmatch(s)
+	if m == nil || strings.HasSuffix(s, "P") || strings.HasSuffix(s, "T") {
+		return 0, ErrorSyntax
+	}
+	var days float64
+	for i, u := range []float64{base.JulianYear, 7, 1, 1. / 24, 1. / 1440, 1. / 86400} {
+		if c := m[i+2]; c != "" {
+			v, _ := strconv.ParseFloat(c, 64)
+			days += v * u
+		}
+	}
+	if m[1] == "-" {
+		days = -days
+	}
+	return days, nil
+}
+
+// FormatDuration formats a duration in days as an ISO 8601 duration of
+// days, hours, minutes, and seconds, "P1DT12H" for example.
+//
+// Seconds are rounded to the nanosecond.
+func FormatDuration(days float64) string {
+	s := "P"
+	if days < 0 {
+		s = "-P"
+		days = -days
+	}
+	d := math.Floor(days)
+	ns := int64(math.Round((days - d) * 86400e9))
+	if ns >= 86400e9 {
+		d++
+		ns -= 86400e9
+	}
+	if d > 0 {
+		s += strconv.FormatFloat(d, 'f', -1, 64) + "D"
+	}
+	if ns == 0 {
+		if d == 0 {
+			s += "T0S"
+		}
+		return s
+	}
+	s += "T"
+	if h := ns / 3600e9; h > 0 {
+		s += strconv.FormatInt(h, 10) + "H"
+	}
+	if m := ns / 60e9 % 60; m > 0 {
+		s += strconv.FormatInt(m, 10) + "M"
+	}
+	if sec := ns % 60e9; sec > 0 {
+		s += strconv.FormatFloat(float64(sec)/1e9, 'f', -1, 64) + "S"
+	}
+	return s
+}
@@ -0,0 +1,128 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package timescale
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/soniakeys/unit"
+)
+
+// LeapSecond is an entry of a table of leap seconds.
+type LeapSecond struct {
+	JD          float64   // UTC julian day from which the offset applies
+	TAIminusUTC unit.Time // accumulated offset, 10s at 1972 January 1
+}
+
+// LeapTable is a table of leap seconds in increasing order of date.
+type LeapTable []LeapSecond
+
+// mjd returns a LeapSecond from a modified julian day.
+func mjd(d float64, s unit.Time) LeapSecond {
+	return LeapSecond{d + base.JMod, s}
+}
+
+// Leap is the built in table of leap seconds, through the leap second of
+// 2016 December 31.
+var Leap = LeapTable{
+	mjd(41317, 10), // 1972 Jan 1
+	mjd(41499, 11), // 1972 Jul 1
+	mjd(41683, 12), // 1973 Jan 1
+	mjd(42048, 13), // 1974 Jan 1
+	mjd(42413, 14), // 1975 Jan 1
+	mjd(42778, 15), // 1976 Jan 1
+	mjd(43144, 16), // 1977 Jan 1
+	mjd(43509, 17), // 1978 Jan 1
+	mjd(43874, 18), // 1979 Jan 1
+	mjd(44239, 19), // 1980 Jan 1
+	mjd(44786, 20), // 1981 Jul 1
+	mjd(45151, 21), // 1982 Jul 1
+	mjd(45516, 22), // 1983 Jul 1
+	mjd(46247, 23), // 1985 Jul 1
+	mjd(47161, 24), // 1988 Jan 1
+	mjd(47892, 25), // 1990 Jan 1
+	mjd(48257, 26), // 1991 Jan 1
+	mjd(48804, 27), // 1992 Jul 1
+	mjd(49169, 28), // 1993 Jul 1
+	mjd(49534, 29), // 1994 Jul 1
+	mjd(50083, 30), // 1996 Jan 1
+	mjd(50630, 31), // 1997 Jul 1
+	mjd(51179, 32), // 1999 Jan 1
+	mjd(53736, 33), // 2006 Jan 1
+	mjd(54832, 34), // 2009 Jan 1
+	mjd(56109, 35), // 2012 Jul 1
+	mjd(57204, 36), // 2015 Jul 1
+	mjd(57754, 37), // 2017 Jan 1
+}
+
+// TAIminusUTC returns the accumulated leap seconds at a UTC julian day.
+//
+// The result is 0 before the first entry of the table.
+func (t LeapTable) TAIminusUTC(jd float64) unit.Time {
+	i := sort.Search(len(t), func(i int) bool { return t[i].JD > jd })
+	if i == 0 {
+		return 0
+	}
+	return t[i-1].TAIminusUTC
+}
+
+// leapSecondBefore returns true if the table has a positive leap second,
+// 23:59:60, ending at UTC julian day jd.
+func (t LeapTable) leapSecondBefore(jd float64) bool {
+	i := sort.Search(len(t), func(i int) bool { return t[i].JD >= jd-1e-9 })
+	return i > 0 && i < len(t) && math.Abs(t[i].JD-jd) < 1e-9 &&
+		t[i].TAIminusUTC == t[i-1].TAIminusUTC+1
+}
+
+// ErrorNoLeap is returned by LoadLeapSeconds when a file has no entries.
+var ErrorNoLeap = errors.New("No leap seconds found")
+
+// ntpMJD is the modified julian day of the NTP epoch, 1900 January 1.
+const ntpMJD = 15020
+
+// LoadLeapSeconds loads a table of leap seconds from text in the format of
+// the IERS file Leap_Second.dat or of the file leap-seconds.list
+// distributed with time zone data.
+// 读取闰秒表文件
+//
+// Lines of Leap_Second.dat give MJD, day, month, year, and TAI-UTC.  Lines
+// of leap-seconds.list give NTP seconds from 1900 and TAI-UTC.  Comments
+// beginning with # are ignored.
+func LoadLeapSeconds(r io.Reader) (LeapTable, error) {
+	var t LeapTable
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		l := s.Text()
+		if i := strings.IndexByte(l, '#'); i >= 0 {
+			l = l[:i]
+		}
+		f := strings.Fields(l)
+		if len(f) != 2 && len(f) != 5 {
+			continue
+		}
+		d, err1 := strconv.ParseFloat(f[0], 64)
+		Δ, err2 := strconv.ParseFloat(f[len(f)-1], 64)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		if len(f) == 2 {
+			d = d/86400 + ntpMJD
+		}
+		t = append(t, mjd(d, unit.Time(Δ)))
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	if len(t) == 0 {
+		return nil, ErrorNoLeap
+	}
+	return t, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+// Timescale: Conversions between time scales.
+// 时间尺度转换
+//
+// This package is not from the book, although chapter 10 discusses the
+// time scales.  Functions of the library take a julian day as "jd" for
+// Universal Time or "jde" for Dynamical Time.  A JD here carries its time
+// scale, and To converts between scales:
+//
+//	UTC is Coordinated Universal Time, the scale of civil time and of
+//	    Go time.Time, differing from TAI by whole leap seconds since 1972.
+//	UT1 is Universal Time, following the rotation of the Earth.
+//	TAI is International Atomic Time.
+//	TT is Terrestrial Time, TAI + 32.184s, the jde of the library.
+//	TDB is Barycentric Dynamical Time, within 2ms of TT.
+//
+// UTC is related to TAI through a table of leap seconds, Leap by default.
+// Before 1972 UTC is taken to be UT1, which it followed within a small
+// fraction of a second.  UT1 is related to TT through ΔT from package
+// deltat, by deltat.DeltaT by default.
+//
+// Parse reads dates in ISO 8601 extended format, negative years included,
+// and julian or modified julian days, each with an optional scale, as
+// "-0500-03-01T12:00:00 TT" or "MJD 51544.5 UTC".  Methods ISO, FormatJD
+// and FormatMJD write them.  ParseDuration and FormatDuration handle ISO
+// 8601 durations in days.
+package timescale
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/soniakeys/unit"
+)
+
+// Scale identifies a time scale.
+type Scale int
+
+// Time scales.
+const (
+	UTC Scale = iota
+	UT1
+	TAI
+	TT
+	TDB
+)
+
+var scaleNames = [...]string{"UTC", "UT1", "TAI", "TT", "TDB"}
+
+// String returns the abbreviation of the scale, "TT" for example.
+func (s Scale) String() string {
+	if s < 0 || int(s) >= len(scaleNames) {
+		return fmt.Sprintf("Scale(%d)", int(s))
+	}
+	return scaleNames[s]
+}
+
+// JD is a julian day in a time scale.
+type JD struct {
+	JD    float64
+	Scale Scale
+}
+
+// String formats the julian day with its scale, "2451545.000000 TT" for
+// example.
+func (j JD) String() string {
+	return fmt.Sprintf("%.6f %s", j.JD, j.Scale)
+}
+
+// FromTime returns the UTC julian day of a time.Time.
+func FromTime(t time.Time) JD {
+	return JD{julian.TimeToJD(t), UTC}
+}
+
+// Time returns the time.Time of the julian day, converted to UTC.
+func (j JD) Time() time.Time {
+	return julian.JDToTime(j.To(UTC).JD)
+}
+
+// To converts the julian day to scale s, with the default Converter.
+func (j JD) To(s Scale) JD {
+	return (&Converter{}).Convert(j, s)
+}
+
+// Converter holds the data used in conversions.
+type Converter struct {
+	Leap  LeapTable     // leap seconds, Leap if nil
+	Model *deltat.Model // ΔT, deltat.DeltaT if nil
+}
+
+// TTminusTAI is the constant offset of TT from TAI.
+const TTminusTAI unit.Time = 32.184
+
+// TDBminusTT returns the difference TDB - TT at a julian day, by the
+// approximation of the Explanatory Supplement, good to about 30µs.
+func TDBminusTT(jd float64) unit.Time {
+	g := unit.AngleFromDeg(357.53 + .98560028*(jd-base.J2000))
+	return unit.Time(.001657*g.Sin() + .000014*(2*g).Sin())
+}
+
+// leap returns the table of leap seconds.
+func (c *Converter) leap() LeapTable {
+	if c.Leap == nil {
+		return Leap
+	}
+	return c.Leap
+}
+
+// deltaT returns ΔT at jde.
+func (c *Converter) deltaT(jde float64) unit.Time {
+	var ΔT unit.Time
+	if c.Model == nil {
+		ΔT, _ = deltat.DeltaT(jde)
+	} else {
+		ΔT, _ = c.Model.At(jde)
+	}
+	return ΔT
+}
+
+// leapStart returns the start of the table of leap seconds.
+func (c *Converter) leapStart() float64 {
+	if t := c.leap(); len(t) > 0 {
+		return t[0].JD
+	}
+	return math.Inf(1)
+}
+
+// toTT returns the TT julian day of j.
+func (c *Converter) toTT(j JD) float64 {
+	switch j.Scale {
+	case UTC:
+		if j.JD < c.leapStart() {
+			return j.JD + c.deltaT(j.JD).Day()
+		}
+		return j.JD + (c.leap().TAIminusUTC(j.JD) + TTminusTAI).Day()
+	case UT1:
+		return j.JD + c.deltaT(j.JD).Day()
+	case TAI:
+		return j.JD + TTminusTAI.Day()
+	case TDB:
+		return j.JD - TDBminusTT(j.JD).Day()
+	}
+	return j.JD
+}
+
+// fromTT returns the julian day in scale s of TT julian day tt.
+func (c *Converter) fromTT(tt float64, s Scale) float64 {
+	switch s {
+	case UTC:
+		tai := tt - TTminusTAI.Day()
+		// the offset at the UTC day, found from TAI
+		utc := tai - c.leap().TAIminusUTC(tai).Day()
+		if utc < c.leapStart() {
+			return c.fromTT(tt, UT1)
+		}
+		return tai - c.leap().TAIminusUTC(utc).Day()
+	case UT1:
+		return tt - c.deltaT(tt).Day()
+	case TAI:
+		return tt - TTminusTAI.Day()
+	case TDB:
+		return tt + TDBminusTT(tt).Day()
+	}
+	return tt
+}
+
+// Convert converts j to scale s.
+// 时间尺度转换
+func (c *Converter) Convert(j JD, s Scale) JD {
+	if j.Scale == s {
+		return j
+	}
+	return JD{c.fromTT(c.toTT(j), s), s}
+}
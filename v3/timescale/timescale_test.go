@@ -0,0 +1,201 @@
+// Copyright 2013 Sonia Keys
+// License: MIT
+
+package timescale_test
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mooncaker816/learnmeeus/v3/base"
+	"github.com/mooncaker816/learnmeeus/v3/deltat"
+	"github.com/mooncaker816/learnmeeus/v3/julian"
+	"github.com/mooncaker816/learnmeeus/v3/timescale"
+	"github.com/soniakeys/unit"
+)
+
+func ExampleJD_To() {
+	j := timescale.FromTime(time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC))
+	fmt.Println(j)
+	fmt.Printf("%.3f\n", unit.TimeFromDay(j.To(timescale.TT).JD-j.JD))
+	fmt.Println(j.To(timescale.TDB).Time().Format(time.RFC3339Nano))
+	// Output:
+	// 2457754.500000 UTC
+	// 69.184
+	// 2017-01-01T00:00:00Z
+}
+
+func TestConvert(t *testing.T) {
+	scales := []timescale.Scale{timescale.UTC, timescale.UT1,
+		timescale.TAI, timescale.TT, timescale.TDB}
+	for _, jd := range []float64{
+		julian.CalendarGregorianToJD(1900, 1, 1),
+		julian.CalendarGregorianToJD(1980, 6, 1.3),
+		julian.CalendarGregorianToJD(2020, 3, 20.7),
+		julian.CalendarGregorianToJD(2100, 1, 1),
+	} {
+		for _, s1 := range scales {
+			for _, s2 := range scales {
+				j := timescale.JD{JD: jd, Scale: s1}
+				r := j.To(s2).To(s1)
+				if Δ := unit.TimeFromDay(r.JD - jd); math.Abs(Δ.Sec()) > 1e-4 {
+					t.Error(j, s2, Δ)
+				}
+			}
+		}
+	}
+	// TDB - TT at its maximum
+	if d := timescale.TDBminusTT(julian.CalendarGregorianToJD(2000, 4, 3)); math.Abs(d.Sec()-.00166) > .00002 {
+		t.Error(d)
+	}
+	// before 1972 UTC is UT1
+	j := timescale.JD{JD: julian.CalendarGregorianToJD(1900, 1, 1), Scale: timescale.UTC}
+	if j.To(timescale.TT) != (timescale.JD{JD: j.JD, Scale: timescale.UT1}).To(timescale.TT) {
+		t.Error("UTC before 1972")
+	}
+	// a Model with an override
+	tab := &deltat.Table{Year: []float64{2019, 2021}, ΔT: []unit.Time{60, 60}}
+	c := timescale.Converter{Model: &deltat.Model{Tables: []*deltat.Table{tab}}}
+	r := c.Convert(timescale.JD{JD: julian.CalendarGregorianToJD(2020, 1, 1), Scale: timescale.TT}, timescale.UT1)
+	if Δ := unit.TimeFromDay(julian.CalendarGregorianToJD(2020, 1, 1) - r.JD); math.Abs(Δ.Sec()-60) > 1e-4 {
+		t.Error(Δ)
+	}
+}
+
+func TestLoadLeapSeconds(t *testing.T) {
+	for _, f := range []string{`#  File expires on 28 June 2024
+#    MJD        Date        TAI-UTC (s)
+#           day month year
+#    ---    --------------   ------
+    41317.0    1  1 1972       10
+    41499.0    1  7 1972       11
+    57754.0    1  1 2017       37
+`, `# leap-seconds.list
+2272060800	10	# 1 Jan 1972
+2287785600	11	# 1 Jul 1972
+3692217600	37	# 1 Jan 2017
+`} {
+		l, err := timescale.LoadLeapSeconds(strings.NewReader(f))
+		if err != nil || len(l) != 3 {
+			t.Fatal(l, err)
+		}
+		for i, e := range l {
+			if e != timescale.Leap[[]int{0, 1, 27}[i]] {
+				t.Error(e)
+			}
+		}
+		if s := l.TAIminusUTC(julian.CalendarGregorianToJD(2016, 12, 31.9)); s != 11 {
+			t.Error(s)
+		}
+	}
+}
+
+func ExampleParse() {
+	for _, s := range []string{
+		"-0500-03-01T12:00:00 TT",
+		"2000-01-01T12:00:00Z",
+		"2024-03-20T11:06:04.25+08:00",
+		"JD 2451545.0 TDB",
+		"MJD 51544 UT1",
+	} {
+		j, err := timescale.Parse(s)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(j.FormatJD(6), "=", j.ISO(3))
+	}
+	// Output:
+	// JD 1538498.000000 TT = -0500-03-01T12:00:00.000 TT
+	// JD 2451545.000000 UTC = 2000-01-01T12:00:00.000 UTC
+	// JD 2460389.629216 UTC = 2024-03-20T03:06:04.250 UTC
+	// JD 2451545.000000 TDB = 2000-01-01T12:00:00.000 TDB
+	// JD 2451544.500000 UT1 = 2000-01-01T00:00:00.000 UT1
+}
+
+func ExampleParseDuration() {
+	d, _ := timescale.ParseDuration("P1DT12H30M0.5S")
+	fmt.Printf("%.9f\n", d)
+	fmt.Println(timescale.FormatDuration(d))
+	fmt.Println(timescale.FormatDuration(-1. / 86400))
+	// Output:
+	// 1.520839120
+	// P1DT12H30M0.5S
+	// -PT1S
+}
+
+func TestParse(t *testing.T) {
+	for _, c := range []struct {
+		s  string
+		jd float64
+	}{
+		{"2000-01-01", 2451544.5},
+		{"2000-01-01T12:00", 2451545},
+		{"1957-10-04T19:26:24", 2436116.31},
+		{"-4712-01-01T12:00:00", julian.CalendarJulianToJD(-4712, 1, 1.5) + 38},
+		{"+12000-01-01", julian.CalendarGregorianToJD(12000, 1, 1)},
+		{"2000-02-29T00:00:00-01:30", 2451603.5 + 1.5/24},
+		{"2000-01-01T12:00:00Z UTC", 2451545},
+		// leap seconds, at the 0h that follows
+		{"2016-12-31T23:59:60Z", julian.CalendarGregorianToJD(2017, 1, 1)},
+		{"2016-12-31T23:59:60.5", julian.CalendarGregorianToJD(2017, 1, 1)},
+		{"2017-01-01T08:59:60+09:00", julian.CalendarGregorianToJD(2017, 1, 1)},
+		{"1972-06-30T23:59:60 UTC", julian.CalendarGregorianToJD(1972, 7, 1)},
+		{"jd2451545", 2451545},
+		{"MJD 0 TAI", base.JMod},
+		{"2451545.25 TT", 2451545.25},
+	} {
+		j, err := timescale.Parse(c.s)
+		if err != nil || math.Abs(j.JD-c.jd) > 1e-9 {
+			t.Error(c.s, j, err)
+		}
+	}
+	for _, s := range []string{"", "2000-1-1", "2001-02-29", "1900-02-29",
+		"2000-13-01", "2000-01-01T24:00", "2000-01-01T12:00:60",
+		"2000-01-01 XT", "2000-01-01T00:00+01:00 TT",
+		"2000-01-01T12:00:00Z TT", "MJD 2000-01-01",
+		"2015-12-31T23:59:60Z", "2016-12-31T23:58:60Z",
+		"2016-12-31T23:59:60 TT", "2016-12-31T23:59:61Z",
+		"1971-12-31T23:59:60Z",
+		"JD", "1 2 3"} {
+		if _, err := timescale.Parse(s); err != timescale.ErrorSyntax {
+			t.Error(s, err)
+		}
+	}
+	// round trip with rounding at the carry
+	j := timescale.JD{JD: 2451544.5 - 1e-12, Scale: timescale.TT}
+	if s := j.ISO(3); s != "2000-01-01T00:00:00.000 TT" {
+		t.Error(s)
+	}
+	for _, s := range []string{"-0500-03-01T12:00:00.125 TT",
+		"0000-02-29T23:59:59.500 UTC", "9999-12-31T00:00:01.000 TDB"} {
+		j, err := timescale.Parse(s)
+		if err != nil || j.ISO(3) != s {
+			t.Error(s, j.ISO(3), err)
+		}
+	}
+	if s := (timescale.JD{JD: base.J2000, Scale: timescale.TT}).FormatMJD(1); s != "MJD 51544.5 TT" {
+		t.Error(s)
+	}
+	// durations
+	for _, c := range []struct {
+		s string
+		d float64
+	}{{"P1Y", 365.25}, {"P2W", 14}, {"-P1.5D", -1.5}, {"PT6H", .25},
+		{"PT1M", 1. / 1440}, {"+PT43200S", .5}} {
+		if d, err := timescale.ParseDuration(c.s); err != nil || math.Abs(d-c.d) > 1e-12 {
+			t.Error(c.s, d, err)
+		}
+	}
+	for _, s := range []string{"", "P", "PT", "P1M", "P1DT", "1D", "P1H"} {
+		if _, err := timescale.ParseDuration(s); err != timescale.ErrorSyntax {
+			t.Error(s, err)
+		}
+	}
+	if s := timescale.FormatDuration(0); s != "PT0S" {
+		t.Error(s)
+	}
+}